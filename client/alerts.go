@@ -0,0 +1,408 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+	"github.com/PantheonTechnologies/vpptop/gui"
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+	"github.com/PantheonTechnologies/vpptop/stats"
+	tui "github.com/gizak/termui/v3"
+)
+
+// alertNotification is how long an alert is shown in the notification.
+const alertNotification = 5 * time.Second
+
+// alertMetrics are the tabs of the metrics of the alert rules.
+var alertMetrics = map[string]int{
+	"interface.rx-pps":      Interfaces,
+	"interface.tx-pps":      Interfaces,
+	"interface.rx-bps":      Interfaces,
+	"interface.tx-bps":      Interfaces,
+	"interface.rx-util":     Interfaces,
+	"interface.tx-util":     Interfaces,
+	"interface.drops/s":     Interfaces,
+	"interface.rx-miss/s":   Interfaces,
+	"interface.rx-no-buf/s": Interfaces,
+	"interface.rx-errors/s": Interfaces,
+	"interface.tx-errors/s": Interfaces,
+	"node.vectors/call":     Nodes,
+	"node.clocks":           Nodes,
+	"node.calls/s":          Nodes,
+	"node.vectors/s":        Nodes,
+	"error.count":           Errors,
+	"error.rate":            Errors,
+	"memory.free":           Memory,
+}
+
+// ifaceCounterMetrics are the interface counters
+// in the history of the per second metrics.
+var ifaceCounterMetrics = map[string]string{
+	"interface.drops/s":     "Drops",
+	"interface.rx-miss/s":   "RxMiss",
+	"interface.rx-no-buf/s": "RxNoBuf",
+	"interface.rx-errors/s": "RxErrors",
+	"interface.tx-errors/s": "TxErrors",
+}
+
+// alertKey identifies the state of a rule for an entity.
+type alertKey struct {
+	rule   int
+	entity string
+}
+
+// alertState is the state of a rule for an entity.
+type alertState struct {
+	// pending is the time since the value is over the
+	// threshold of an alert which is not raised yet.
+	pending time.Time
+	raised  bool
+	value   float64
+}
+
+// alertEvent is an alert raised or cleared for an entity.
+type alertEvent struct {
+	rule   config.AlertRule
+	tab    int
+	entity string
+	value  float64
+	raised bool
+	time   time.Time
+}
+
+// String returns the message of the event.
+func (e alertEvent) String() string {
+	threshold, above := e.rule.Threshold()
+	op := ">"
+	if !above {
+		op = "<"
+	}
+	if !e.raised {
+		return fmt.Sprintf("cleared %s on %s: %s", e.rule.Name, e.entity, xtui.FormatNumber(e.value, ""))
+	}
+	return fmt.Sprintf("%s %s on %s: %s %s %s", e.rule.Severity, e.rule.Name, e.entity,
+		xtui.FormatNumber(e.value, ""), op, xtui.FormatNumber(threshold, ""))
+}
+
+// alerts evaluates the alert rules. An alert is raised once the value
+// stays over the threshold for the hold-down time of the rule, and is
+// cleared once the value crosses the clear value of the rule.
+type alerts struct {
+	sync.Mutex
+	rules  []config.AlertRule
+	states map[alertKey]*alertState
+}
+
+// newAlerts returns the alerts of the rules.
+func newAlerts(rules []config.AlertRule) *alerts {
+	a := new(alerts)
+	a.setRules(rules)
+	return a
+}
+
+// setRules replaces the rules, forgetting the raised
+// alerts. The rules of unknown metrics are skipped.
+func (a *alerts) setRules(rules []config.AlertRule) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.rules = nil
+	for _, rule := range rules {
+		if _, ok := alertMetrics[rule.Metric]; !ok {
+			log.Printf("unknown alert metric %q\n", rule.Metric)
+			continue
+		}
+		a.rules = append(a.rules, rule)
+	}
+	a.states = make(map[alertKey]*alertState)
+}
+
+// watches returns true if the tab has alert rules.
+func (a *alerts) watches(tab int) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	for _, rule := range a.rules {
+		if alertMetrics[rule.Metric] == tab {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate evaluates the rules of the tab with the metrics of its
+// entities keyed by the entity, and returns the raised and cleared
+// alerts. The alerts of the entities missing in the samples are cleared,
+// so the samples must come from a successful poll.
+func (a *alerts) evaluate(tab int, samples map[string]sample, now time.Time) []alertEvent {
+	a.Lock()
+	defer a.Unlock()
+
+	var events []alertEvent
+	for i, rule := range a.rules {
+		if alertMetrics[rule.Metric] != tab {
+			continue
+		}
+		threshold, above := rule.Threshold()
+		clear := rule.ClearValue()
+		for entity, metrics := range samples {
+			v, ok := metrics[rule.Metric]
+			if !ok || rule.Match != "" && !config.MatchName(rule.Match, entityName(tab, entity)) {
+				continue
+			}
+			key := alertKey{rule: i, entity: entity}
+			state, ok := a.states[key]
+			if !ok {
+				state = new(alertState)
+				a.states[key] = state
+			}
+			state.value = v
+
+			switch {
+			case !state.raised && (above && v > threshold || !above && v < threshold):
+				if state.pending.IsZero() {
+					state.pending = now
+				}
+				if now.Sub(state.pending) >= rule.For {
+					state.raised = true
+					events = append(events, alertEvent{rule: rule, tab: tab, entity: entity, value: v, raised: true, time: now})
+				}
+			case !state.raised:
+				delete(a.states, key)
+			case above && v <= clear || !above && v >= clear:
+				delete(a.states, key)
+				events = append(events, alertEvent{rule: rule, tab: tab, entity: entity, value: v, time: now})
+			}
+		}
+		for key, state := range a.states {
+			if _, ok := samples[key.entity]; ok || key.rule != i {
+				continue
+			}
+			delete(a.states, key)
+			if state.raised {
+				events = append(events, alertEvent{rule: rule, tab: tab, entity: key.entity, value: state.value, time: now})
+			}
+		}
+	}
+	return events
+}
+
+// raised returns the number of the raised alerts of the tab.
+func (a *alerts) raised(tab int) int {
+	a.Lock()
+	defer a.Unlock()
+
+	count := 0
+	for key, state := range a.states {
+		if state.raised && alertMetrics[a.rules[key.rule].Metric] == tab {
+			count++
+		}
+	}
+	return count
+}
+
+// severity returns the highest severity of the raised alerts of the
+// entity with the name displayed at the tab, false if there are none.
+func (a *alerts) severity(tab int, name string) (string, bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	severity, found := "", false
+	for key, state := range a.states {
+		rule := a.rules[key.rule]
+		if !state.raised || alertMetrics[rule.Metric] != tab || entityName(tab, key.entity) != name {
+			continue
+		}
+		if !found || severityLevel(rule.Severity) > severityLevel(severity) {
+			severity, found = rule.Severity, true
+		}
+	}
+	return severity, found
+}
+
+// severityLevel orders the severities.
+func severityLevel(severity string) int {
+	switch severity {
+	case config.SeverityInfo:
+		return 1
+	case config.SeverityWarning:
+		return 2
+	case config.SeverityCritical:
+		return 3
+	}
+	return 0
+}
+
+// entityName returns the name of the entity displayed at the tab.
+// The nodes are kept per thread, see nodeKeys.
+func entityName(tab int, entity string) string {
+	if tab == Nodes {
+		if i := strings.LastIndexByte(entity, '#'); i >= 0 {
+			return entity[:i]
+		}
+	}
+	return entity
+}
+
+// entityOf returns the name of the entity displayed at the tab,
+// e.g. the interface name or the node and reason of an error counter.
+func entityOf(tab int, entry xtui.TableRows) string {
+	if len(entry) == 0 || len(entry[0]) == 0 {
+		return ""
+	}
+	cell := func(cs columns, name string) string {
+		if i := cs.index(name); i != NoColumn && i < len(entry[0]) {
+			return strings.TrimSpace(entry[0][i])
+		}
+		return ""
+	}
+	switch tab {
+	case Interfaces:
+		return strings.TrimSpace(entry[0][0])
+	case Nodes:
+		return cell(nodeColumns, "NodeName")
+	case Errors:
+		return cell(errorColumns, "Node") + "/" + cell(errorColumns, "Reason")
+	}
+	return entry[0][0]
+}
+
+// alertHighlighter returns the highlighter of the entries
+// of the tab with raised alerts, styled by their severity.
+func (app *App) alertHighlighter(tab int) xtui.EntryHighlighter {
+	return func(entry xtui.TableRows) (tui.Style, bool) {
+		severity, ok := app.alerts.severity(tab, entityOf(tab, entry))
+		if !ok {
+			return tui.Style{}, false
+		}
+		theme := gui.CurrentTheme()
+		switch severity {
+		case config.SeverityInfo:
+			return theme.SeverityInfo, true
+		case config.SeverityCritical:
+			return theme.SeverityCritical, true
+		}
+		return theme.SeverityWarning, true
+	}
+}
+
+// alertBadge returns the badge of a tab with the raised alerts.
+func alertBadge(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[%d]", count)
+}
+
+// checkAlerts evaluates the alert rules of the tab with the samples of
+// its entities. The raised and cleared alerts are logged and notified
+// and the number of the raised alerts is shown next to the tab name.
+func (app *App) checkAlerts(tab int, samples map[string]sample) {
+	if !app.alerts.watches(tab) {
+		return
+	}
+	events := app.alerts.evaluate(tab, samples, time.Now())
+	if len(events) == 0 {
+		return
+	}
+	for _, event := range events {
+		log.Printf("alert: %v\n", event)
+	}
+	app.alertHooks.notify(events)
+	text := events[len(events)-1].String()
+	if len(events) > 1 {
+		text += fmt.Sprintf(" (+%d more)", len(events)-1)
+	}
+	count := app.alerts.raised(tab)
+	app.gui.Exec(func() {
+		app.gui.SetTabBadge(tab, alertBadge(count))
+		app.gui.Notify(text, alertNotification)
+	})
+}
+
+// ifaceAlertSamples returns the alert metrics of the interfaces.
+func (app *App) ifaceAlertSamples(rates []ifaceRates) map[string]sample {
+	samples := make(map[string]sample, len(rates))
+	for _, r := range rates {
+		s := sample{
+			"interface.rx-pps": r.RxPps,
+			"interface.tx-pps": r.TxPps,
+			"interface.rx-bps": r.RxBps,
+			"interface.tx-bps": r.TxBps,
+		}
+		if r.RxUtil != unknownUtil {
+			s["interface.rx-util"] = r.RxUtil
+			s["interface.tx-util"] = r.TxUtil
+		}
+		for metric, counter := range ifaceCounterMetrics {
+			if v, ok := app.ifaceHistory.rate(r.InterfaceName, counter); ok {
+				s[metric] = v
+			}
+		}
+		samples[r.InterfaceName] = s
+	}
+	return samples
+}
+
+// nodeAlertSamples returns the alert metrics of the nodes of each thread.
+func (app *App) nodeAlertSamples(nodes []stats.Node) map[string]sample {
+	keys := nodeKeys(nodes)
+	samples := make(map[string]sample, len(nodes))
+	for i, node := range nodes {
+		s := sample{
+			"node.vectors/call": node.VectorsPerCall,
+			"node.clocks":       node.Clocks,
+		}
+		if v, ok := app.nodeHistory.rate(keys[i], "Calls"); ok {
+			s["node.calls/s"] = v
+		}
+		if v, ok := app.nodeHistory.rate(keys[i], "Vectors"); ok {
+			s["node.vectors/s"] = v
+		}
+		samples[keys[i]] = s
+	}
+	return samples
+}
+
+// errorAlertSamples returns the alert metrics of the error counters.
+func (app *App) errorAlertSamples(errors []stats.Error) map[string]sample {
+	samples := make(map[string]sample, len(errors))
+	for _, e := range errors {
+		s := sample{"error.count": float64(e.Value)}
+		if v, ok := app.errorHistory.rate(errorKey(e), "Counter"); ok {
+			s["error.rate"] = v
+		}
+		samples[errorKey(e)] = s
+	}
+	return samples
+}
+
+// memoryAlertSamples returns the alert metrics of the threads
+// from the samples recorded by recordMemory.
+func memoryAlertSamples(recorded map[string]sample) map[string]sample {
+	samples := make(map[string]sample, len(recorded))
+	for thread, s := range recorded {
+		samples[thread] = sample{"memory.free": s["Free"]}
+	}
+	return samples
+}
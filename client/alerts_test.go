@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+)
+
+func TestAlerts_Evaluate(t *testing.T) {
+	above, clear := 100.0, 50.0
+	a := newAlerts([]config.AlertRule{
+		{Name: "drops", Metric: "interface.drops/s", Match: "eth*", Above: &above, Clear: &clear, For: 2 * time.Second},
+		{Name: "unknown", Metric: "interface.unknown", Above: &above},
+	})
+	if !a.watches(Interfaces) || a.watches(Nodes) {
+		t.Fatalf("Error occured got:%v,%v; want:true,false", a.watches(Interfaces), a.watches(Nodes))
+	}
+
+	start := time.Now()
+	tests := []struct {
+		drops  float64
+		after  time.Duration
+		events int
+		raised int
+	}{
+		// the alert is raised after the value stays above the threshold for 2s.
+		{drops: 150, after: 0, events: 0, raised: 0},
+		{drops: 150, after: time.Second, events: 0, raised: 0},
+		{drops: 150, after: 2 * time.Second, events: 1, raised: 1},
+		// it stays raised until the value drops to the clear value.
+		{drops: 80, after: 3 * time.Second, events: 0, raised: 1},
+		{drops: 40, after: 4 * time.Second, events: 1, raised: 0},
+		// a flapping value doesn't raise it.
+		{drops: 150, after: 5 * time.Second, events: 0, raised: 0},
+		{drops: 40, after: 6 * time.Second, events: 0, raised: 0},
+		{drops: 150, after: 7 * time.Second, events: 0, raised: 0},
+	}
+	for i, test := range tests {
+		samples := map[string]sample{
+			"eth0": {"interface.drops/s": test.drops},
+			"tap0": {"interface.drops/s": test.drops},
+		}
+		events := a.evaluate(Interfaces, samples, start.Add(test.after))
+		if len(events) != test.events || a.raised(Interfaces) != test.raised {
+			t.Errorf("test %d: Error occured got:%v,%v; want:%v,%v", i, len(events), a.raised(Interfaces), test.events, test.raised)
+		}
+	}
+
+	// the alert of a missing entity is cleared.
+	a.evaluate(Interfaces, map[string]sample{"eth0": {"interface.drops/s": 150}}, start.Add(10*time.Second))
+	if _, ok := a.severity(Interfaces, "eth0"); !ok {
+		t.Errorf("Error occured got:%v; want:%v", ok, true)
+	}
+	if events := a.evaluate(Interfaces, map[string]sample{}, start.Add(11*time.Second)); len(events) != 1 || events[0].raised {
+		t.Errorf("Error occured got:%v; want:%v", events, "cleared eth0")
+	}
+}
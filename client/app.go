@@ -18,21 +18,22 @@ package client
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/PantheonTechnologies/vpptop/config"
 	"github.com/PantheonTechnologies/vpptop/gui"
 	"github.com/PantheonTechnologies/vpptop/gui/views"
 	"github.com/PantheonTechnologies/vpptop/gui/xtui"
 	"github.com/PantheonTechnologies/vpptop/stats"
 )
 
-// Index for each TableView. (total of 5 tabs)
+// Index for each TableView. (total of 6 tabs)
 const (
-	Interfaces = iota
+	Overview = iota
+	Interfaces
 	Nodes
 	Errors
 	Memory
@@ -46,23 +47,68 @@ const (
 	RowsPerMemory = 8
 )
 
+// tabNames are the names of the tabs, as used in the config.
+var tabNames = []string{"Overview", "Interfaces", "Nodes", "Errors", "Memory", "Threads"}
+
 type App struct {
-	gui *gui.TermWindow
-	vpp *stats.VPP
+	gui    *gui.TermWindow
+	vpp    *stats.VPP
+	tables []*views.TableView
+
+	// current configuration.
+	cfg *config.Config
+	// version of the connected VPP.
+	version string
+	// session is the key under which the gui state is saved.
+	session string
+	// interval carries the new poll interval on config reload.
+	interval chan time.Duration
 
 	// Cache for interface stats to
 	// be able to calculate bytes/s packates/s.
 	IfCache []stats.Interface
+	// ifCacheTime is the time of the poll of the IfCache.
+	ifCacheTime time.Time
+	// overviewPoll are the counters of the previous poll of the overview.
+	overviewPoll *overviewPoll
+	// peaks samples the interface counters within the poll interval.
+	peaks *peakSampler
+	// ifaceSessions are the ranges of the interface rates since
+	// the counters were cleared, keyed by the interface name.
+	ifaceSessions map[string]*ifaceSession
+	// recent counters of the interfaces, nodes, errors and
+	// memory, recorded while their tab is displayed.
+	ifaceHistory  *history
+	nodeHistory   *history
+	errorHistory  *history
+	memoryHistory *history
+	// marks are the baselines of the counters shown in the delta mode.
+	marks *marks
+	// chart is the displayed chart, nil if there is none.
+	chart *chartSpec
 
 	// sortBy carries information used at sorting stats
 	// for each tab.
-	sortBy []struct {
-		asc   bool
-		field int
-	}
+	sortBy []sortOrder
 
 	// current gui tab.
 	currTab int
+	// compact is true if the interfaces are displayed
+	// one per row in the compactIfaces table.
+	compact       bool
+	compactIfaces *views.TableView
+	// highlight is true if the changed cells are highlighted.
+	highlight bool
+	// units describes how the values are formatted.
+	units units
+	// alerts evaluates the alert rules.
+	alerts *alerts
+	// alertHooks are invoked by the raised and cleared alerts.
+	alertHooks *alertHooks
+	// grouped is true for the tabs with the entries grouped.
+	grouped []bool
+	// hideIdle is true for the tabs with the idle entries hidden.
+	hideIdle []bool
 
 	// go routine management.
 	wg       *sync.WaitGroup
@@ -72,8 +118,18 @@ type App struct {
 	cancel   context.CancelFunc
 }
 
-func NewApp(lightTheme bool) *App {
+func NewApp(cfg *config.Config) *App {
 	app := new(App)
+	app.cfg = cfg
+	app.interval = make(chan time.Duration)
+	app.ifaceHistory = newHistory(cfg.History, cfg.Interval)
+	app.nodeHistory = newHistory(cfg.History, cfg.Interval)
+	app.errorHistory = newHistory(cfg.History, cfg.Interval)
+	app.memoryHistory = newHistory(cfg.History, cfg.Interval)
+	app.peaks = newPeakSampler()
+	app.marks = new(marks)
+	app.alerts = newAlerts(cfg.Alerts)
+	app.alertHooks = newAlertHooks(cfg.AlertHooks)
 
 	app.sortLock = new(sync.Mutex)
 	app.tabLock = new(sync.Mutex)
@@ -81,116 +137,92 @@ func NewApp(lightTheme bool) *App {
 
 	app.vpp = new(stats.VPP)
 	app.wg = new(sync.WaitGroup)
-	app.sortBy = make([]struct {
-		asc   bool
-		field int
-	}, 5)
-
-	for i := range app.sortBy {
-		app.sortBy[i].field = NoColumn
-		app.sortBy[i].asc = !app.sortBy[i].asc
+	app.sortBy = make([]sortOrder, len(tabNames))
+	app.grouped = make([]bool, len(tabNames))
+	app.hideIdle = make([]bool, len(tabNames))
+	app.hideIdle[Errors] = hideIdleDefault(Errors)
+
+	app.tables = []*views.TableView{
+		// overview tab.
+		views.NewTableView(
+			[]string{},
+			xtui.TableRows{{"Overview", xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}},
+			0,
+			1,
+			[]int{40, 16, 16, views.TableColResizedWithWindow},
+		),
+		// interface tab.
+		views.NewTableView(
+			ifaceColumns.names(),
+			ifaceTable.header(),
+			ifaceColumns.index("Name"),
+			RowsPerIface,
+			ifaceTable.widths(),
+		),
+		// node tab.
+		newColumnsView(nodeColumns, "NodeName"),
+		// errors tab.
+		newColumnsView(errorColumns, "Node"),
+		// memory tab.
+		views.NewTableView(
+			[]string{},
+			xtui.TableRows{{"Thread/ID/Name", "Current memory usage per Thread"}},
+			MemoryStatName,
+			RowsPerMemory,
+			[]int{30, views.TableColResizedWithWindow},
+		),
+		// threads tab.
+		newColumnsView(threadColumns, ""),
+	}
+	// interface header columns differ from the sort items.
+	app.tables[Interfaces].SetSortItems(ifaceColumns.layoutSortItems(len(ifaceTable)))
+	app.tables[Interfaces].SetFilterFields(ifaceColumns.layoutFields())
+
+	app.compactIfaces = views.NewTableView(
+		ifaceColumns.names(),
+		compactIfaceColumns.header(),
+		compactIfaceColumns.index("Name"),
+		1,
+		compactIfaceColumns.widths(),
+	)
+	app.compactIfaces.SetAlignments(compactIfaceColumns.alignments())
+	app.compactIfaces.SetSortItems(ifaceColumns.indexes(compactIfaceColumns.names()...))
+	app.compactIfaces.SetEntryHighlighter(app.alertHighlighter(Interfaces))
+	for tab := range app.tables {
+		app.tables[tab].SetEntryHighlighter(app.alertHighlighter(tab))
+	}
+
+	tabs := make([]gui.TabView, len(app.tables))
+	for i := range app.tables {
+		tabs[i] = app.tables[i]
 	}
 
 	app.gui = gui.NewTermWindow(
 		16*time.Millisecond,
-		[]gui.TabView{
-			// interface tab.
-			views.NewTableView(
-				[]string{
-					"Name",
-					"Index",
-					"State",
-					"MTU-L3",
-					"MTU-IP4",
-					"MTU-IP6",
-					"MTU-MPLS",
-					"RxPackets",
-					"RxBytes",
-					"RxErrors",
-					"RxUnicast-packets",
-					"RxUnicast-bytes",
-					"RxMulticast-packets",
-					"RxMulticast-bytes",
-					"RxBroadcast-packets",
-					"RxBroadcast-bytes",
-					"TxPackets",
-					"TxBytes",
-					"TxErrors",
-					"TxUnicastMiss-packets",
-					"TxUnicastMiss-bytes",
-					"TxMulticast-packets",
-					"TxMulticast-bytes",
-					"TxBroadcast-packets",
-					"TxBroadcast-bytes",
-					"Drops",
-					"Punts",
-					"IP4",
-					"IP6",
-				},
-				xtui.TableRows{{"Name", "Idx", "State", "MTU(L3/IP4/IP6/MPLS)", "RxCounters", "RxCount", "TxCounters", "TxCount", "Drops", "Punts", "IP4", "IP6"}},
-				IfaceStatIfaceName,
-				RowsPerIface,
-				[]int{24, 5, 5, 20, 10, 16, 11, 16, 11, 11, 11, views.TableColResizedWithWindow},
-				lightTheme,
-			),
-			// node tab.
-			views.NewTableView(
-				[]string{
-					"NodeName",
-					"NodeIndex",
-					"Clocks",
-					"Vectors",
-					"Calls",
-					"Suspends",
-					"Vectors/Calls",
-				},
-				xtui.TableRows{{"NodeName", "NodeIndex", "Clocks", "Vectors", "Calls", "Suspends", "Vectors/Calls"}},
-				NodeStatNodeName,
-				1,
-				[]int{50, 10, views.TableColResizedWithWindow, views.TableColResizedWithWindow, views.TableColResizedWithWindow, views.TableColResizedWithWindow, 22},
-				lightTheme,
-			),
-			// errors tab.
-			views.NewTableView(
-				[]string{"Counter", "Node", "Reason"},
-				xtui.TableRows{{"Counter", "Node", "Reason"}},
-				ErrorStatErrorNodeName,
-				1,
-				nil,
-				lightTheme,
-			),
-			// memory tab.
-			views.NewTableView(
-				[]string{},
-				xtui.TableRows{{"Thread/ID/Name", "Current memory usage per Thread"}},
-				MemoryStatName,
-				RowsPerMemory,
-				[]int{30, views.TableColResizedWithWindow},
-				lightTheme,
-			),
-			// threads tab.
-			views.NewTableView(
-				[]string{},
-				xtui.TableRows{{"ID", "Name", "Type", "PID", "CPUID", "Core", "CPUSocket"}},
-				NoColumn,
-				1,
-				nil,
-				lightTheme,
-			),
-		},
-		[]string{"Interfaces", "Nodes", "Errors", "Memory", "Threads"},
+		tabs,
+		tabNames,
 		[]int{Interfaces, Nodes, Errors},
 		views.NewExitView(),
 	)
+	// registered before the default or restored tab is activated,
+	// so the polled tab follows it.
+	app.gui.AddOnTabSwitchCallback(func(event gui.Event) {
+		app.tabLock.Lock()
+		defer app.tabLock.Unlock()
+		app.currTab = event.Payload.(int)
+	})
 
 	return app
 }
 
-// Init initializes app.
-func (app *App) Init(soc, raddr string) error {
+// Init initializes app. If raddr is empty, the app connects
+// to the local VPP using the sockets from the config. The gui state
+// saved under the session key is restored.
+func (app *App) Init(raddr, session string) error {
+	app.session = session
 	switch raddr {
 	case "":
-		if err := app.vpp.Connect(soc); err != nil {
+		if err := app.vpp.Connect(app.cfg.StatsSocket, app.cfg.BinapiSocket); err != nil {
 			return err
 		}
 	default:
@@ -208,14 +240,336 @@ func (app *App) Init(soc, raddr string) error {
 		return err
 	}
 	app.gui.SetVersion(v)
+	app.version = v
+
+	app.initTabs()
+	app.setHighlight(app.cfg.HighlightChanges)
+	app.setUnits(configUnits(app.cfg))
 
 	return nil
 }
 
+// initTabs applies the config of the tabs, activates the default
+// tab and restores the gui state of the session over them.
+func (app *App) initTabs() {
+	for tab, name := range tabNames {
+		app.applyTabConfig(tab, app.cfg.Tab(name))
+		if strings.EqualFold(name, app.cfg.DefaultTab) {
+			app.gui.SetActiveTab(tab)
+		}
+	}
+	app.restoreSession()
+}
+
+// Reload applies the new configuration and theme. The sockets
+// are not changed, as they are used only on start, neither are
+// the sort, filter, columns and grouping of the tabs, which
+// might have been changed interactively.
+func (app *App) Reload(cfg *config.Config, theme *gui.Theme) {
+	app.gui.Exec(func() {
+		app.cfg = cfg
+		app.gui.SetTheme(theme)
+		// the gui themes the displayed views only, not
+		// the interfaces view of the other layout.
+		app.compactIfaces.SetTheme(theme)
+		app.tables[Interfaces].SetTheme(theme)
+		app.setHighlight(cfg.HighlightChanges)
+		app.setUnits(configUnits(cfg))
+		for _, h := range []*history{app.ifaceHistory, app.nodeHistory, app.errorHistory, app.memoryHistory} {
+			h.resize(cfg.History, cfg.Interval)
+		}
+		app.alerts.setRules(cfg.Alerts)
+		app.alertHooks.set(cfg.AlertHooks)
+		for tab := range tabNames {
+			app.gui.SetTabBadge(tab, "")
+		}
+	})
+	// the update go-routine might have already exited.
+	select {
+	case app.interval <- cfg.Interval:
+	case <-time.After(cfg.Interval):
+	}
+}
+
+// applyTabConfig applies the sort, filter, visible columns,
+// column widths and grouping to the tab at index.
+func (app *App) applyTabConfig(tab int, tc config.TabConfig) {
+	app.gui.SetFilter(tab, tc.Filter)
+	app.tables[tab].SetColumns(tc.Columns)
+	app.tables[tab].SetWidths(tc.Widths)
+	if tab == Interfaces {
+		app.compactIfaces.SetColumns(tc.Columns)
+		app.compactIfaces.SetWidths(tc.Widths)
+		app.setCompact(tc.Compact)
+	}
+	app.setGroup(tab, tc.Group)
+	hide := hideIdleDefault(tab)
+	if tc.HideIdle != nil {
+		hide = *tc.HideIdle
+	}
+	app.setHideIdle(tab, hide)
+
+	var order sortOrder
+	keys := append([]string{tc.Sort}, tc.ThenBy...)
+	for i, key := range keys {
+		asc := !tc.Descending
+		if i > 0 {
+			asc = !strings.HasPrefix(key, "-")
+			key = strings.TrimPrefix(key, "-")
+		}
+		if key == "" {
+			continue
+		}
+		field := NoColumn
+		for j, item := range app.tables[tab].ItemsList() {
+			if strings.EqualFold(item, key) {
+				field = j
+			}
+		}
+		if field == NoColumn {
+			log.Printf("unknown sort column %q for tab %s\n", key, tabNames[tab])
+			continue
+		}
+		if len(order) < maxSortKeys {
+			order = append(order, sortKey{field: field, asc: asc})
+		}
+	}
+
+	app.sortLock.Lock()
+	app.sortBy[tab] = order
+	app.sortLock.Unlock()
+	app.updateSortIndicator(tab, order)
+}
+
+// updateSortIndicator marks the header column of the primary sort key.
+func (app *App) updateSortIndicator(tab int, order sortOrder) {
+	item, asc := NoColumn, false
+	if len(order) != 0 {
+		item, asc = order[0].field, order[0].asc
+	}
+	app.tables[tab].SetSortIndicator(item, asc)
+	if tab == Interfaces {
+		app.compactIfaces.SetSortIndicator(item, asc)
+	}
+}
+
+// sortOrder returns the column to sort the tab by and the order.
+func (app *App) sortOrder(tab int) sortOrder {
+	app.sortLock.Lock()
+	defer app.sortLock.Unlock()
+	return app.sortBy[tab]
+}
+
+// newColumnsView returns a table view with one entry per row,
+// generated from the columns. The rows are filtered by the
+// column with the filter name, if any. The selection follows
+// the entry identified by the key columns, or by the filter
+// column if there are none.
+func newColumnsView(cs columns, filter string) *views.TableView {
+	v := views.NewTableView(cs.names(), cs.header(), cs.index(filter), 1, cs.widths())
+	v.SetAlignments(cs.alignments())
+	v.SetFilterFields(cs.fields())
+	if keys := cs.keys(); keys != nil {
+		v.SetKeyColumns(keys...)
+	}
+	return v
+}
+
+// table returns the table displayed at the tab.
+func (app *App) table(tab int) *views.TableView {
+	if tab == Interfaces && app.isCompact() {
+		return app.compactIfaces
+	}
+	return app.tables[tab]
+}
+
+// isCompact returns true if the interfaces are displayed one per row.
+func (app *App) isCompact() bool {
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	return app.compact
+}
+
+// setCompact switches the layout of the interfaces tab.
+func (app *App) setCompact(compact bool) {
+	app.tabLock.Lock()
+	app.compact = compact
+	app.tabLock.Unlock()
+	app.gui.SetView(Interfaces, app.table(Interfaces))
+}
+
+// restoreSession restores the gui state saved on the last exit.
+func (app *App) restoreSession() {
+	s, found, err := config.LoadSession(app.cfg.SessionPath(), app.session)
+	if err != nil {
+		log.Printf("error occurred while loading session: %v\n", err)
+		return
+	}
+	if !found {
+		return
+	}
+	for tab, name := range tabNames {
+		ts, ok := s.Tabs[name]
+		if !ok {
+			continue
+		}
+		app.applyTabConfig(tab, ts.TabConfig)
+		app.table(tab).SetPosition(ts.Offset, ts.Selected)
+	}
+	for tab, name := range tabNames {
+		if name == s.Tab {
+			app.gui.SetActiveTab(tab)
+		}
+	}
+}
+
+// saveSession saves the current gui state, so it
+// can be restored on the next start.
+func (app *App) saveSession() {
+	s := config.Session{
+		Tab:  tabNames[app.gui.ActiveTab()],
+		Tabs: make(map[string]config.TabSession),
+	}
+
+	app.sortLock.Lock()
+	for tab, name := range tabNames {
+		var ts config.TabSession
+		ts.Filter = app.gui.Filter(tab)
+		ts.Columns = app.table(tab).VisibleColumns()
+		ts.Widths = app.table(tab).Widths()
+		ts.Compact = tab == Interfaces && app.isCompact()
+		ts.Group = app.isGrouped(tab)
+		if hasIdle(tab) {
+			hide := app.isHideIdle(tab)
+			ts.HideIdle = &hide
+		}
+		for i, key := range app.sortBy[tab] {
+			name := app.tables[tab].ItemsList()[key.field]
+			if i == 0 {
+				ts.Sort = name
+				ts.Descending = !key.asc
+				continue
+			}
+			if !key.asc {
+				name = "-" + name
+			}
+			ts.ThenBy = append(ts.ThenBy, name)
+		}
+		ts.Offset, ts.Selected = app.table(tab).Position()
+		s.Tabs[name] = ts
+	}
+	app.sortLock.Unlock()
+
+	if err := config.SaveSession(app.cfg.SessionPath(), app.session, s); err != nil {
+		log.Printf("error occurred while saving session: %v\n", err)
+	}
+}
+
+// poll polls the stats of the tab and evaluates its alert rules.
+// The view of the tab is updated only if it is shown.
+func (app *App) poll(tab int, show bool) {
+	switch tab {
+	case Overview:
+		o := app.pollOverview()
+		if !show {
+			return
+		}
+		app.gui.ViewAtTab(Overview).Update(overviewRows(o, app.getUnits()))
+	case Interfaces:
+		ifaces, err := app.vpp.GetInterfaces()
+		if err != nil {
+			log.Printf("error occured while polling interface stats: %v\n", err)
+		}
+		app.recordInterfaces(ifaces)
+		rates := app.rates(ifaces)
+		// the entities of a failed poll are missing, not gone.
+		if err == nil {
+			app.checkAlerts(Interfaces, app.ifaceAlertSamples(rates))
+		}
+		if !show {
+			return
+		}
+		if mk := app.marks.baseline(); mk != nil {
+			rates = mk.ifaceDeltas(rates)
+		}
+		if app.isHideIdle(Interfaces) {
+			rates = app.activeIfaces(rates)
+		}
+		ifaceColumns.sort(rates, app.sortOrder(Interfaces))
+		if app.isCompact() {
+			app.compactIfaces.Update(views.Entries{Rows: app.formatCompactInterfaces(rates), Values: entryValues(rates)})
+		} else {
+			app.tables[Interfaces].Update(views.Entries{Rows: app.formatInterfaces(rates), Values: entryValues(rates)})
+		}
+	case Nodes:
+		nodes, err := app.vpp.GetNodes()
+		if err != nil {
+			log.Printf("error occured while polling nodes stats: %v\n", err)
+		}
+		trends := app.nodeTrends(nodes)
+		if err == nil {
+			app.checkAlerts(Nodes, app.nodeAlertSamples(nodes))
+		}
+		if !show {
+			return
+		}
+		if mk := app.marks.baseline(); mk != nil {
+			trends = mk.nodeDeltas(trends)
+		}
+		if app.isHideIdle(Nodes) {
+			trends = app.activeNodes(trends)
+		}
+		nodeColumns.sort(trends, app.sortOrder(Nodes))
+		app.gui.ViewAtTab(Nodes).Update(views.Entries{Rows: app.formatNodes(trends), Values: entryValues(trends)})
+	case Errors:
+		errors, err := app.vpp.GetErrors()
+		if err != nil {
+			log.Printf("error occured while polling errors stats: %v\n", err)
+		}
+		trends := app.errorTrends(errors)
+		if err == nil {
+			app.checkAlerts(Errors, app.errorAlertSamples(errors))
+		}
+		if !show {
+			return
+		}
+		if mk := app.marks.baseline(); mk != nil {
+			trends = mk.errorDeltas(trends)
+		}
+		if app.isHideIdle(Errors) {
+			trends = app.activeErrors(trends)
+		}
+		errorColumns.sort(trends, app.sortOrder(Errors))
+		app.gui.ViewAtTab(Errors).Update(views.Entries{Rows: app.formatErrors(trends), Values: entryValues(trends)})
+	case Memory:
+		memstats, err := app.vpp.Memory()
+		if err != nil {
+			log.Printf("error occured while polling memory stats: %v\n", err)
+		}
+		free := app.recordMemory(memstats, memstatsPerThread)
+		if err == nil {
+			app.checkAlerts(Memory, memoryAlertSamples(free))
+		}
+		if !show {
+			return
+		}
+		app.gui.ViewAtTab(Memory).Update(app.formatMemstats(memstats))
+	case Threads:
+		threads, err := app.vpp.Threads()
+		if err != nil {
+			log.Printf("error occured while polling threads stats: %v\n", err)
+		}
+		threadColumns.sort(threads, app.sortOrder(Threads))
+		app.gui.ViewAtTab(Threads).Update(threadColumns.rows(threads))
+	}
+}
+
 // Start starts the application.
 func (app *App) Run() {
 	var ctx context.Context
 	ctx, app.cancel = context.WithCancel(context.Background())
+	// before the polls which might raise alerts.
+	app.alertHooks.start(ctx, app.wg)
 	currTab := func() int {
 		app.tabLock.Lock()
 		defer app.tabLock.Unlock()
@@ -224,61 +578,24 @@ func (app *App) Run() {
 
 	app.wg.Add(1)
 
-
 	go func() {
-		updateTicker := time.NewTicker(1 * time.Second).C
+		updateTicker := time.NewTicker(app.cfg.Interval)
+		defer func() { updateTicker.Stop() }()
 		for {
 			select {
-			case <-updateTicker:
+			case interval := <-app.interval:
+				updateTicker.Stop()
+				updateTicker = time.NewTicker(interval)
+			case <-updateTicker.C:
 				app.vppLock.Lock()
 
-				switch currTab() {
-				case Interfaces:
-					ifaces, err := app.vpp.GetInterfaces()
-					if err != nil {
-						log.Printf("error occured while polling interface stats: %v\n", err)
+				curr := currTab()
+				for tab := range tabNames {
+					if tab == curr || app.alerts.watches(tab) {
+						app.poll(tab, tab == curr)
 					}
-					app.sortLock.Lock()
-					s := app.sortBy[Interfaces]
-					app.sortLock.Unlock()
-
-					app.sortInterfaceStats(ifaces, s.field, s.asc)
-					app.gui.ViewAtTab(Interfaces).Update(app.formatInterfaces(ifaces))
-				case Nodes:
-					nodes, err := app.vpp.GetNodes()
-					if err != nil {
-						log.Printf("error occured while polling nodes stats: %v\n", err)
-					}
-					app.sortLock.Lock()
-					s := app.sortBy[Nodes]
-					app.sortLock.Unlock()
-
-					app.sortNodeStats(nodes, s.field, s.asc)
-					app.gui.ViewAtTab(Nodes).Update(app.formatNodes(nodes))
-				case Errors:
-					errors, err := app.vpp.GetErrors()
-					if err != nil {
-						log.Printf("error occured while polling errors stats: %v\n", err)
-					}
-					app.sortLock.Lock()
-					s := app.sortBy[Errors]
-					app.sortLock.Unlock()
-
-					app.sortErrorStats(errors, s.field, s.asc)
-					app.gui.ViewAtTab(Errors).Update(app.formatErrors(errors))
-				case Memory:
-					memstats, err := app.vpp.Memory()
-					if err != nil {
-						log.Printf("error occured while polling memory stats: %v\n", err)
-					}
-					app.gui.ViewAtTab(Memory).Update(app.formatMemstats(memstats))
-				case Threads:
-					threads, err := app.vpp.Threads()
-					if err != nil {
-						log.Printf("error occured while polling threads stats: %v\n", err)
-					}
-					app.gui.ViewAtTab(Threads).Update(app.formatThreads(threads))
 				}
+				app.updateChart()
 				app.vppLock.Unlock()
 			case <-ctx.Done():
 				app.wg.Done()
@@ -287,6 +604,11 @@ func (app *App) Run() {
 		}
 	}()
 
+	if app.cfg.PeakSampling > 0 {
+		app.wg.Add(1)
+		go app.samplePeaks(ctx, app.cfg.PeakSampling, currTab)
+	}
+
 	app.gui.AddOnClearCallback(func(event gui.Event) {
 		tab := event.Payload.(int)
 		// launch in background
@@ -302,104 +624,107 @@ func (app *App) Run() {
 					log.Printf("error occured while clearing interface stats: %v\n", err)
 				}
 				app.IfCache = nil
+				app.ifaceSessions = nil
+				app.peaks.reset()
+				app.ifaceHistory.clear()
 			case Nodes:
 				if err := app.vpp.ClearRuntimeCounters(); err != nil {
 					log.Printf("error occured while clearing node stats: %v\n", err)
 				}
+				app.nodeHistory.clear()
 			case Errors:
 				if err := app.vpp.ClearErrorCounters(); err != nil {
 					log.Printf("error occured while clearing error stats: %v\n", err)
 				}
+				app.errorHistory.clear()
 			}
 		}()
 	})
 
 	app.gui.AddOnSortCallback(func(event gui.Event) {
 		payload := event.Payload.(gui.SortMetadata)
+		if payload.CurrRow < 0 || payload.CurrRow >= len(app.tables[payload.CurrTab].ItemsList()) {
+			return
+		}
 
-		app.wg.Add(1)
-		go func() {
-			defer app.wg.Done()
-
-			app.sortLock.Lock()
-			defer app.sortLock.Unlock()
+		app.sortLock.Lock()
+		order := app.sortBy[payload.CurrTab].by(payload.CurrRow)
+		app.sortBy[payload.CurrTab] = order
+		app.sortLock.Unlock()
 
-			switch payload.CurrTab {
-			case Interfaces:
-				app.sortBy[Interfaces].field = payload.CurrRow
-				app.sortBy[Interfaces].asc = !app.sortBy[Interfaces].asc
-			case Nodes:
-				app.sortBy[Nodes].field = payload.CurrRow
-				app.sortBy[Nodes].asc = !app.sortBy[Nodes].asc
-			case Errors:
-				app.sortBy[Errors].field = payload.CurrRow
-				app.sortBy[Errors].asc = !app.sortBy[Errors].asc
-			}
-		}()
+		app.updateSortIndicator(payload.CurrTab, order)
 	})
 
 	app.gui.AddOnExitCallback(func(_ gui.Event) {
+		app.saveSession()
 		app.cancel()
 		app.wg.Wait()
 		app.gui.Destroy()
 		app.vpp.Disconnect()
 	})
 
-	app.gui.AddOnTabSwitchCallback(func(event gui.Event) {
-		app.tabLock.Lock()
-		defer app.tabLock.Unlock()
-		app.currTab = event.Payload.(int)
+	app.gui.AddKeybinding(gui.KeyLayout, func(event gui.Event) {
+		if event.Payload.(int) == Interfaces {
+			app.setCompact(!app.isCompact())
+		}
+	})
+
+	app.gui.AddKeybinding(gui.KeyHighlight, func(_ gui.Event) {
+		app.setHighlight(!app.isHighlight())
+	})
+
+	app.gui.AddKeybinding(gui.KeyUnits, func(_ gui.Event) {
+		u := app.getUnits()
+		u.human = !u.human
+		app.setUnits(u)
+	})
+
+	app.gui.AddKeybinding(gui.KeyRates, func(_ gui.Event) {
+		u := app.getUnits()
+		u.byteRates = !u.byteRates
+		app.setUnits(u)
+	})
+
+	app.gui.AddKeybinding(gui.KeyGroup, func(event gui.Event) {
+		tab := event.Payload.(int)
+		app.setGroup(tab, !app.isGrouped(tab))
+	})
+
+	app.gui.AddKeybinding(gui.KeyChart, func(event gui.Event) {
+		app.showChart(event.Payload.(int))
+	})
+
+	app.gui.AddKeybinding(gui.KeyIdle, func(event gui.Event) {
+		app.toggleIdle(event.Payload.(int))
+	})
+
+	app.gui.AddKeybinding(gui.KeyMark, func(_ gui.Event) {
+		app.addMark()
+	})
+
+	app.gui.AddKeybinding(gui.KeyMarks, func(_ gui.Event) {
+		app.showMarks()
+	})
+
+	app.gui.AddKeybinding(gui.KeyDelta, func(_ gui.Event) {
+		app.toggleDelta()
 	})
 
 	app.gui.Start()
 }
 
 // formatInterfaces formats interface stats to xtui.TableRows
-func (app *App) formatInterfaces(ifaces []stats.Interface) xtui.TableRows {
-	nameToIdx := make(map[string]int)
-	for i, iface := range app.IfCache {
-		nameToIdx[iface.InterfaceName] = i
+func (app *App) formatInterfaces(ifaces []ifaceRates) xtui.TableRows {
+	if len(ifaces) == 0 {
+		// all interfaces may be hidden as idle.
+		rows := make(xtui.TableRows, RowsPerIface)
+		for i := range rows {
+			rows[i] = make([]string, len(ifaceTable))
+		}
+		return rows
 	}
-
-	rows := make(xtui.TableRows, RowsPerIface*len(ifaces))
+	rows := ifaceColumns.layoutRows(ifaces, RowsPerIface, len(ifaceTable), app.getUnits())
 	for i, iface := range ifaces {
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], iface.InterfaceName)
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.InterfaceIndex))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], iface.State)
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprintf("%d/%d/%d/%d", iface.MTU[0], iface.MTU[1], iface.MTU[2], iface.MTU[3]))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], "Packets")
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.Rx.Packets))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], "Packets")
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.Tx.Packets))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.Drops))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.Punts))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.IP4))
-		rows[RowsPerIface*i] = append(rows[RowsPerIface*i], fmt.Sprint(iface.IP6))
-
-		rxbbs := uint64(0) //rx bytes/s
-		txbbs := uint64(0) //tx bytes/s
-		rxpps := uint64(0) //rx packets/s
-		txpps := uint64(0) //tx packets/s
-
-		if idx, ok := nameToIdx[iface.InterfaceName]; ok {
-			// Calculate bytes/s, packets/s
-			rxbbs = iface.Rx.Bytes - app.IfCache[idx].Rx.Bytes
-			txbbs = iface.Tx.Bytes - app.IfCache[idx].Tx.Bytes
-
-			rxpps = iface.Rx.Packets - app.IfCache[idx].Rx.Packets
-			txpps = iface.Tx.Packets - app.IfCache[idx].Tx.Packets
-		}
-		rows[RowsPerIface*i+1] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Packets/s", fmt.Sprint(rxpps), "Packets/s", fmt.Sprint(txpps), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+2] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Bytes", fmt.Sprint(iface.Rx.Bytes), "Bytes", fmt.Sprint(iface.Tx.Bytes), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+3] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Bytes/s", fmt.Sprint(rxbbs), "Bytes/s", fmt.Sprint(txbbs), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+4] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Errors", fmt.Sprint(iface.RxErrors), "Errors", fmt.Sprint(iface.TxErrors), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+5] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Unicast", fmt.Sprintf("%d/%d", iface.RxUnicast.Packets, iface.RxUnicast.Bytes), "UnicastMiss", fmt.Sprintf("%d/%d", iface.TxUnicast.Packets, iface.TxUnicast.Bytes), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+6] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Multicast", fmt.Sprintf("%d/%d", iface.RxMulticast.Packets, iface.RxMulticast.Bytes), "Multicast", fmt.Sprintf("%d/%d", iface.TxMulticast.Packets, iface.TxMulticast.Bytes), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+7] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Broadcast", fmt.Sprintf("%d/%d", iface.RxBroadcast.Packets, iface.RxBroadcast.Bytes), "Broadcast", fmt.Sprintf("%d/%d", iface.TxBroadcast.Packets, iface.TxBroadcast.Bytes), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+8] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "NoBuf", fmt.Sprint(iface.RxNoBuf), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+9] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, "Miss", fmt.Sprint(iface.RxMiss), xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-		rows[RowsPerIface*i+10] = []string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell}
-
 		// start from the second row, the first is taken up
 		// by the interface name.
 		row := RowsPerIface*i + 1
@@ -411,38 +736,45 @@ func (app *App) formatInterfaces(ifaces []stats.Interface) xtui.TableRows {
 			row++
 		}
 	}
-	app.IfCache = ifaces
+	return rows
+}
 
+// formatCompactInterfaces formats interface stats to xtui.TableRows
+// with one row per interface.
+func (app *App) formatCompactInterfaces(ifaces []ifaceRates) xtui.TableRows {
+	rows := compactIfaceColumns.formatRows(ifaces, app.getUnits())
+	if len(rows) == 0 {
+		rows = append(rows, make([]string, len(compactIfaceColumns)))
+	}
 	return rows
 }
 
-// formatNodes formats nodes stats to xtui.TableRows
-func (app *App) formatNodes(nodes []stats.Node) xtui.TableRows {
-	rows := make(xtui.TableRows, len(nodes))
-	for i, node := range nodes {
-		rows[i] = strings.Split(fmt.Sprintf("%s %d %d %d %d %d %.2f", node.Name, node.Index, uint64(node.Clocks), node.Vectors, node.Calls, node.Suspends, node.VectorsPerCall), " ")
+// formatNodes formats node stats to xtui.TableRows
+func (app *App) formatNodes(nodes []nodeTrend) xtui.TableRows {
+	rows := nodeColumns.formatRows(nodes, app.getUnits())
+	if len(rows) == 0 {
+		rows = append(rows, make([]string, len(nodeColumns)))
 	}
 	return rows
 }
 
 // formatErrors formats error stats to xtui.TableRows
-func (app *App) formatErrors(errors []stats.Error) xtui.TableRows {
-	rows := make(xtui.TableRows, len(errors))
-	for i, errorC := range errors {
-		rows[i] = strings.Split(fmt.Sprintf("%d;%s;%s", errorC.Value, errorC.Node, errorC.Name), ";")
-	}
+func (app *App) formatErrors(errors []errorTrend) xtui.TableRows {
+	rows := errorColumns.formatRows(errors, app.getUnits())
 	if len(rows) == 0 {
-		rows = append(rows, []string{"", "", ""})
+		rows = append(rows, make([]string, len(errorColumns)))
 	}
 	return rows
-
 }
 
+// memstatsPerThread is the number of lines of the memory stats per thread.
+const memstatsPerThread = 7
+
 // formatMemstats formats memory stats to xtui.TableRows
 func (app *App) formatMemstats(memstats []string) xtui.TableRows {
 	// stats.Memory returns the stats as []string
 	// where 7 rows corresponds to one entry.
-	const rowsPerEntry = 7
+	const rowsPerEntry = memstatsPerThread
 	count := len(memstats) / rowsPerEntry         // number of entries.
 	rows := make([][]string, RowsPerMemory*count) // our view will have 6 rows per entry.
 	for i := 0; i < count; i++ {
@@ -456,12 +788,3 @@ func (app *App) formatMemstats(memstats []string) xtui.TableRows {
 	}
 	return rows
 }
-
-// formatThreads formats memory stats to xtui.TableRows
-func (app *App) formatThreads(threads []stats.ThreadData) xtui.TableRows {
-	rows := make(xtui.TableRows, len(threads))
-	for i, thread := range threads {
-		rows[i] = strings.Split(fmt.Sprintf("%d %s %s %d %d %d %d", thread.ID, thread.Name, thread.Type, thread.PID, thread.CPUID, thread.Core, thread.CPUSocket), " ")
-	}
-	return rows
-}
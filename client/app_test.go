@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+)
+
+func TestApp_InitTabs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vpptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := config.Default()
+	cfg.DefaultTab = "Nodes"
+	cfg.SessionFile = filepath.Join(dir, "session.json")
+
+	// the default tab is the polled one.
+	app := NewApp(cfg)
+	app.initTabs()
+	if app.currTab != Nodes {
+		t.Errorf("Error occured got:%v; want:%v", tabNames[app.currTab], tabNames[Nodes])
+	}
+
+	// the tab of the session is the polled one.
+	if err := config.SaveSession(cfg.SessionPath(), "stats.sock", config.Session{Tab: "Errors"}); err != nil {
+		t.Fatal(err)
+	}
+	app = NewApp(cfg)
+	app.session = "stats.sock"
+	app.initTabs()
+	if app.currTab != Errors {
+		t.Errorf("Error occured got:%v; want:%v", tabNames[app.currTab], tabNames[Errors])
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+)
+
+// chartSpec describes the chart of the selected entry.
+type chartSpec struct {
+	title string
+	unit  string
+	// series returns the series from the current history.
+	series func() []xtui.Series
+}
+
+// memoryFree matches the free heap memory in the memory stats.
+var memoryFree = regexp.MustCompile(`free: ([0-9.]+[kKMGT]?)`)
+
+// recordMemory records the free heap memory of the threads and returns
+// the recorded samples. The memory stats consist of rowsPerEntry lines
+// per thread.
+func (app *App) recordMemory(memstats []string, rowsPerEntry int) map[string]sample {
+	samples := make(map[string]sample)
+	for i := 0; i+rowsPerEntry <= len(memstats); i += rowsPerEntry {
+		for _, line := range memstats[i : i+rowsPerEntry] {
+			m := memoryFree.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			if free, ok := xtui.ParseNumber(m[1]); ok {
+				samples[memstats[i]] = sample{"Free": free}
+			}
+			break
+		}
+	}
+	app.memoryHistory.record(time.Now(), samples)
+	return samples
+}
+
+// rateSeries returns the series of the increments of the counter per
+// second, multiplied by scale, e.g. 8 for bits from bytes. The time of
+// an increment is the time of the later sample.
+func rateSeries(label string, points []point, scale float64) xtui.Series {
+	s := xtui.Series{Label: label, Values: rates(points)}
+	for i := range s.Values {
+		s.Values[i] *= scale
+		s.Times = append(s.Times, points[i+1].at)
+	}
+	return s
+}
+
+// valueSeries returns the series of the values of the samples.
+func valueSeries(label string, points []point) xtui.Series {
+	s := xtui.Series{Label: label}
+	for _, p := range points {
+		s.Values = append(s.Values, p.value)
+		s.Times = append(s.Times, p.at)
+	}
+	return s
+}
+
+// chartOf returns the chart of the entry displayed at the
+// tab, or nil if the tab has no charts or the entry isn't
+// in the history, e.g. a group header.
+func (app *App) chartOf(tab int, entry xtui.TableRows) *chartSpec {
+	if len(entry) == 0 || len(entry[0]) == 0 {
+		return nil
+	}
+	entity := entityOf(tab, entry)
+
+	switch tab {
+	case Interfaces:
+		name := entity
+		if app.ifaceHistory.values(name, "RxBytes") == nil {
+			return nil
+		}
+		unit, scale := "bps", 8.0
+		if app.getUnits().byteRates {
+			unit, scale = "B/s", 1
+		}
+		return &chartSpec{
+			title: "Interface " + name,
+			unit:  unit,
+			series: func() []xtui.Series {
+				return []xtui.Series{
+					rateSeries("rx", app.ifaceHistory.points(name, "RxBytes"), scale),
+					rateSeries("tx", app.ifaceHistory.points(name, "TxBytes"), scale),
+				}
+			},
+		}
+	case Nodes:
+		name := entity
+		if app.nodeHistory.values(name+"#0", "Vectors/Calls") == nil {
+			return nil
+		}
+		return &chartSpec{
+			title: "Node " + name + " vectors/call",
+			series: func() []xtui.Series {
+				// a series of each thread running the node.
+				var series []xtui.Series
+				for i := 0; ; i++ {
+					points := app.nodeHistory.points(fmt.Sprintf("%s#%d", name, i), "Vectors/Calls")
+					if points == nil {
+						return series
+					}
+					series = append(series, valueSeries(fmt.Sprintf("thread %d", i), points))
+				}
+			},
+		}
+	case Errors:
+		key := entity
+		if app.errorHistory.values(key, "Counter") == nil {
+			return nil
+		}
+		return &chartSpec{
+			title: "Error " + key,
+			unit:  "/s",
+			series: func() []xtui.Series {
+				return []xtui.Series{
+					rateSeries("errors", app.errorHistory.points(key, "Counter"), 1),
+				}
+			},
+		}
+	case Memory:
+		thread := entity
+		if app.memoryHistory.values(thread, "Free") == nil {
+			return nil
+		}
+		return &chartSpec{
+			title: thread + " free heap memory",
+			unit:  "B",
+			series: func() []xtui.Series {
+				return []xtui.Series{valueSeries("free", app.memoryHistory.points(thread, "Free"))}
+			},
+		}
+	}
+	return nil
+}
+
+// showChart shows the chart of the selected entry of the tab.
+func (app *App) showChart(tab int) {
+	spec := app.chartOf(tab, app.table(tab).SelectedRows())
+	if spec == nil {
+		return
+	}
+	app.tabLock.Lock()
+	app.chart = spec
+	app.tabLock.Unlock()
+	app.gui.ShowChart(spec.title, spec.unit, spec.series())
+}
+
+// updateChart updates the displayed chart from the history,
+// until the chart is closed.
+func (app *App) updateChart() {
+	app.tabLock.Lock()
+	spec := app.chart
+	app.tabLock.Unlock()
+	if spec == nil {
+		return
+	}
+	series := spec.series()
+	app.gui.Exec(func() {
+		if !app.gui.UpdateChart(series) {
+			app.tabLock.Lock()
+			if app.chart == spec {
+				app.chart = nil
+			}
+			app.tabLock.Unlock()
+		}
+	})
+}
@@ -0,0 +1,638 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/PantheonTechnologies/vpptop/gui/views"
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+	"github.com/PantheonTechnologies/vpptop/stats"
+	tui "github.com/gizak/termui/v3"
+)
+
+// column describes a single field of a stat entry. The table headers,
+// the rows, the sort menu and the sorting are generated from the list
+// of columns, so adding a field takes a single entry.
+type column struct {
+	// name is the header of the column and the item in the sort menu.
+	name string
+	// unit of the value, empty for values without unit.
+	unit string
+	// width of the column, 0 if the column is resized with the window.
+	width int
+	// align is the alignment of the column.
+	align tui.Alignment
+	// value extracts the value from the stat entry.
+	// The value is one of uint64, float64 or string.
+	value func(entry interface{}) interface{}
+	// format formats the value, if nil fmt.Sprint is used.
+	format func(value interface{}) string
+	// less reports whether the value a is less than b,
+	// if nil the values are compared by their type.
+	less func(a, b interface{}) bool
+	// key marks the columns which identify the entry.
+	key bool
+	// alias is an additional name of the column in the filter.
+	alias string
+	// cell is the position of the value in the layout with multiple
+	// rows per entry, nil if the column is not displayed there.
+	cell *xtui.Cell
+	// label is displayed in the cell left of the value in that layout.
+	label string
+}
+
+// columns is the ordered list of columns of a stat entry.
+type columns []column
+
+// sortKey is the column to sort the entries by and the order.
+type sortKey struct {
+	asc   bool
+	field int
+}
+
+// sortOrder are the keys to sort the entries by, the first one is the
+// primary key, the next ones are used if the previous keys are equal.
+type sortOrder []sortKey
+
+// maxSortKeys is the maximum number of keys of the sort order.
+const maxSortKeys = 3
+
+// units of the column values.
+const (
+	unitPackets  = "packets"
+	unitBytes    = "bytes"
+	unitClocks   = "clocks"
+	unitPps      = "packets/s"
+	unitBps      = "bits/s"
+	unitByteRate = "bytes/s"
+)
+
+// ifaceColumns are the columns of ifaceRates.
+var ifaceColumns = columns{
+	{name: "Name", value: func(e interface{}) interface{} { return e.(ifaceRates).InterfaceName }, cell: at(0, 0)},
+	{name: "Index", align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(ifaceRates).InterfaceIndex) }, cell: at(0, 1)},
+	{name: "State", value: func(e interface{}) interface{} { return e.(ifaceRates).State }, cell: at(0, 2)},
+	{name: "MTU-L3", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return mtu(e.(ifaceRates).Interface, 0) }, alias: "MTU", cell: at(0, 3)},
+	{name: "MTU-IP4", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return mtu(e.(ifaceRates).Interface, 1) }, cell: at(0, 3)},
+	{name: "MTU-IP6", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return mtu(e.(ifaceRates).Interface, 2) }, cell: at(0, 3)},
+	{name: "MTU-MPLS", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return mtu(e.(ifaceRates).Interface, 3) }, cell: at(0, 3)},
+	{name: "RxPackets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Rx.Packets }, cell: at(0, 5), label: "Packets"},
+	{name: "RxBytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Rx.Bytes }, cell: at(2, 5), label: "Bytes"},
+	{name: "RxErrors", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxErrors }, cell: at(4, 5), label: "Errors"},
+	{name: "RxUnicast-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxUnicast.Packets }, cell: at(5, 5), label: "Unicast"},
+	{name: "RxUnicast-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxUnicast.Bytes }, cell: at(5, 5)},
+	{name: "RxMulticast-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMulticast.Packets }, cell: at(6, 5), label: "Multicast"},
+	{name: "RxMulticast-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMulticast.Bytes }, cell: at(6, 5)},
+	{name: "RxBroadcast-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxBroadcast.Packets }, cell: at(7, 5), label: "Broadcast"},
+	{name: "RxBroadcast-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxBroadcast.Bytes }, cell: at(7, 5)},
+	{name: "TxPackets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Tx.Packets }, cell: at(0, 7), label: "Packets"},
+	{name: "TxBytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Tx.Bytes }, cell: at(2, 7), label: "Bytes"},
+	{name: "TxErrors", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxErrors }, cell: at(4, 7), label: "Errors"},
+	{name: "TxUnicastMiss-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxUnicast.Packets }, cell: at(5, 7), label: "UnicastMiss"},
+	{name: "TxUnicastMiss-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxUnicast.Bytes }, cell: at(5, 7)},
+	{name: "TxMulticast-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMulticast.Packets }, cell: at(6, 7), label: "Multicast"},
+	{name: "TxMulticast-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMulticast.Bytes }, cell: at(6, 7)},
+	{name: "TxBroadcast-packets", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxBroadcast.Packets }, cell: at(7, 7), label: "Broadcast"},
+	{name: "TxBroadcast-bytes", unit: unitBytes, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxBroadcast.Bytes }, cell: at(7, 7)},
+	{name: "Drops", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Drops }, cell: at(0, 8)},
+	{name: "Punts", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Punts }, cell: at(0, 9)},
+	{name: "IP4", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).IP4 }, cell: at(0, 10)},
+	{name: "IP6", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).IP6 }, cell: at(0, 11)},
+	{name: "RxNoBuf", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxNoBuf }, cell: at(8, 5), label: "NoBuf"},
+	{name: "RxMiss", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMiss }, cell: at(9, 5), label: "Miss"},
+	{name: "RxPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPps }, format: formatRate, cell: at(1, 5), label: "Packets/s"},
+	{name: "TxPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPps }, format: formatRate, cell: at(1, 7), label: "Packets/s"},
+	{name: "RxBps", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxBps }, format: formatRate},
+	{name: "TxBps", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxBps }, format: formatRate},
+	{name: "RxBytes/s", unit: unitByteRate, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxBps / 8 }, format: formatRate, cell: at(3, 5), label: "Bytes/s"},
+	{name: "TxBytes/s", unit: unitByteRate, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxBps / 8 }, format: formatRate, cell: at(3, 7), label: "Bytes/s"},
+	{name: "Errors", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} {
+		return e.(ifaceRates).RxErrors + e.(ifaceRates).TxErrors
+	}},
+	{name: "Speed", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Speed }, cell: at(0, 13), label: "Speed"},
+	{name: "Duplex", value: func(e interface{}) interface{} { return e.(ifaceRates).Duplex }, cell: at(0, 14)},
+	{name: "Util", align: tui.AlignRight, value: func(e interface{}) interface{} {
+		return math.Max(e.(ifaceRates).RxUtil, e.(ifaceRates).TxUtil)
+	}, format: formatUtil},
+	{name: "RxUtil", align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxUtil }, format: formatGauge, cell: at(2, 13), label: "Util"},
+	{name: "TxUtil", align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxUtil }, format: formatGauge, cell: at(2, 14)},
+	{name: "RxPeak", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPeak }, format: formatRate, cell: at(3, 13), label: "Peak"},
+	{name: "TxPeak", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPeak }, format: formatRate, cell: at(3, 14)},
+	{name: "RxPeakPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPeakPps }, format: formatRate, cell: at(4, 13), label: "Peak/s"},
+	{name: "TxPeakPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPeakPps }, format: formatRate, cell: at(4, 14)},
+	{name: "Burst", value: func(e interface{}) interface{} { return e.(ifaceRates).Burst }, cell: at(5, 13), label: "Burst"},
+	{name: "RxMin", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMin }, format: formatRate, cell: at(6, 13), label: "Min"},
+	{name: "RxAvg", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxAvg }, format: formatRate, cell: at(7, 13), label: "Avg"},
+	{name: "RxMax", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMax }, format: formatRate, cell: at(8, 13), label: "Max"},
+	{name: "TxMin", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMin }, format: formatRate, cell: at(6, 14)},
+	{name: "TxAvg", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxAvg }, format: formatRate, cell: at(7, 14)},
+	{name: "TxMax", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMax }, format: formatRate, cell: at(8, 14)},
+	{name: "RxTrend", value: func(e interface{}) interface{} { return e.(ifaceRates).RxTrend }, cell: at(1, 13), label: "Trend"},
+	{name: "TxTrend", value: func(e interface{}) interface{} { return e.(ifaceRates).TxTrend }, cell: at(1, 14)},
+}
+
+// ifaceTable are the headers and the widths of the columns of the
+// detailed interface layout, the values are placed by ifaceColumns.
+var ifaceTable = columns{
+	{name: "Name", width: 24},
+	{name: "Idx", width: 5},
+	{name: "State", width: 5},
+	{name: "MTU(L3/IP4/IP6/MPLS)", width: 20},
+	{name: "RxCounters", width: 10},
+	{name: "RxCount", width: 16},
+	{name: "TxCounters", width: 11},
+	{name: "TxCount", width: 16},
+	{name: "Drops", width: 11},
+	{name: "Punts", width: 11},
+	{name: "IP4", width: 11},
+	{name: "IP6", width: 11},
+	{name: "Rates", width: 8},
+	{name: "RxRate", width: sparklineWidth + 2},
+	{name: "TxRate"},
+}
+
+// ifaceRates is an interface with the rates
+// per second computed from the previous poll.
+type ifaceRates struct {
+	stats.Interface
+	RxPps, TxPps float64
+	RxBps, TxBps float64
+	// Speed is the link speed in bits per second, 0 if not known.
+	Speed uint64
+	// RxUtil and TxUtil are the percentages of the link
+	// speed used, unknownUtil if the speed is not known.
+	RxUtil, TxUtil float64
+	// RxPeak and TxPeak are the highest bits per second and RxPeakPps
+	// and TxPeakPps the highest packets per second within the interval.
+	RxPeak, TxPeak       float64
+	RxPeakPps, TxPeakPps float64
+	// Burst flags the peaks over the link speed and the rx peak
+	// with missed packets, separated by commas.
+	Burst string
+	// RxMin, RxAvg, RxMax and their tx counterparts are
+	// the bits per second seen since the counters were cleared.
+	RxMin, RxAvg, RxMax float64
+	TxMin, TxAvg, TxMax float64
+	// RxTrend and TxTrend are the sparklines
+	// of the packets received and sent per second.
+	RxTrend, TxTrend string
+	// idle is true if no packets were received
+	// or sent since the previous poll.
+	idle bool
+}
+
+// nodeTrend is a node with the sparkline of its clocks.
+type nodeTrend struct {
+	stats.Node
+	Trend string
+	// idle is true if the node was not called since the previous poll.
+	idle bool
+}
+
+// errorTrend is an error counter with the
+// sparkline of its increments per second.
+type errorTrend struct {
+	stats.Error
+	Trend string
+	// idle is true if the counter did not increase since the previous poll.
+	idle bool
+}
+
+// compactIfaceColumns are the columns of the compact interface layout.
+var compactIfaceColumns = columns{
+	{name: "Name", width: 24, value: func(e interface{}) interface{} { return e.(ifaceRates).InterfaceName }},
+	{name: "State", width: 6, value: func(e interface{}) interface{} { return e.(ifaceRates).State }},
+	{name: "RxPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPps }, format: formatRate},
+	{name: "TxPps", unit: unitPps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPps }, format: formatRate},
+	{name: "RxBps", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxBps }, format: formatRate},
+	{name: "TxBps", unit: unitBps, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxBps }, format: formatRate},
+	{name: "Speed", unit: unitBps, width: 10, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Speed }},
+	{name: "RxUtil", width: 8, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxUtil }, format: formatUtil},
+	{name: "TxUtil", width: 8, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxUtil }, format: formatUtil},
+	{name: "Util", width: gaugeWidth + 3, value: func(e interface{}) interface{} {
+		return gauge(math.Max(e.(ifaceRates).RxUtil, e.(ifaceRates).TxUtil), gaugeWidth)
+	}},
+	{name: "RxPeak", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPeak }, format: formatRate},
+	{name: "TxPeak", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPeak }, format: formatRate},
+	{name: "RxPeakPps", unit: unitPps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxPeakPps }, format: formatRate},
+	{name: "TxPeakPps", unit: unitPps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxPeakPps }, format: formatRate},
+	{name: "Burst", width: 20, value: func(e interface{}) interface{} { return e.(ifaceRates).Burst }},
+	{name: "RxMin", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMin }, format: formatRate},
+	{name: "RxAvg", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxAvg }, format: formatRate},
+	{name: "RxMax", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).RxMax }, format: formatRate},
+	{name: "TxMin", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMin }, format: formatRate},
+	{name: "TxAvg", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxAvg }, format: formatRate},
+	{name: "TxMax", unit: unitBps, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).TxMax }, format: formatRate},
+	{name: "Drops", unit: unitPackets, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(ifaceRates).Drops }},
+	{name: "Errors", unit: unitPackets, width: 12, align: tui.AlignRight, value: func(e interface{}) interface{} {
+		return e.(ifaceRates).RxErrors + e.(ifaceRates).TxErrors
+	}},
+	{name: "RxTrend", width: sparklineWidth + 2, value: func(e interface{}) interface{} { return e.(ifaceRates).RxTrend }},
+	{name: "TxTrend", width: sparklineWidth + 2, value: func(e interface{}) interface{} { return e.(ifaceRates).TxTrend }},
+}
+
+// nodeColumns are the columns of nodeTrend.
+var nodeColumns = columns{
+	{name: "NodeName", alias: "node", width: 50, value: func(e interface{}) interface{} { return e.(nodeTrend).Name }},
+	{name: "NodeIndex", alias: "index", width: 10, align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(nodeTrend).Index) }},
+	{name: "Clocks", unit: unitClocks, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(nodeTrend).Clocks }, format: formatUint},
+	{name: "Vectors", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(nodeTrend).Vectors }},
+	{name: "Calls", align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(nodeTrend).Calls }},
+	{name: "Suspends", align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(nodeTrend).Suspends }},
+	{name: "Vectors/Calls", width: 22, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(nodeTrend).VectorsPerCall }},
+	{name: "Trend", width: sparklineWidth + 2, value: func(e interface{}) interface{} { return e.(nodeTrend).Trend }},
+}
+
+// errorColumns are the columns of errorTrend.
+var errorColumns = columns{
+	{name: "Counter", unit: unitPackets, align: tui.AlignRight, value: func(e interface{}) interface{} { return e.(errorTrend).Value }},
+	{name: "Node", key: true, value: func(e interface{}) interface{} { return e.(errorTrend).Node }},
+	{name: "Reason", key: true, value: func(e interface{}) interface{} { return e.(errorTrend).Name }},
+	{name: "Trend", width: sparklineWidth + 2, value: func(e interface{}) interface{} { return e.(errorTrend).Trend }},
+}
+
+// threadColumns are the columns of stats.ThreadData.
+var threadColumns = columns{
+	{name: "ID", align: tui.AlignRight, key: true, value: func(e interface{}) interface{} { return uint64(e.(stats.ThreadData).ID) }},
+	{name: "Name", value: func(e interface{}) interface{} { return string(e.(stats.ThreadData).Name) }},
+	{name: "Type", value: func(e interface{}) interface{} { return string(e.(stats.ThreadData).Type) }},
+	{name: "PID", align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(stats.ThreadData).PID) }},
+	{name: "CPUID", align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(stats.ThreadData).CPUID) }},
+	{name: "Core", align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(stats.ThreadData).Core) }},
+	{name: "CPUSocket", align: tui.AlignRight, value: func(e interface{}) interface{} { return uint64(e.(stats.ThreadData).CPUSocket) }},
+}
+
+// names returns the names of the columns.
+func (cs columns) names() []string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.name
+	}
+	return names
+}
+
+// index returns the index of the column with the name, or NoColumn.
+func (cs columns) index(name string) int {
+	for i, c := range cs {
+		if c.name == name {
+			return i
+		}
+	}
+	return NoColumn
+}
+
+// indexes returns the index of each column
+// with the name, or NoColumn if there is none.
+func (cs columns) indexes(names ...string) []int {
+	indexes := make([]int, len(names))
+	for i, name := range names {
+		indexes[i] = cs.index(name)
+	}
+	return indexes
+}
+
+// header returns the table header with the column names.
+func (cs columns) header() xtui.TableRows {
+	return xtui.TableRows{cs.names()}
+}
+
+// widths returns the widths of the columns.
+func (cs columns) widths() []int {
+	widths := make([]int, len(cs))
+	for i, c := range cs {
+		widths[i] = c.width
+		if c.width == 0 {
+			widths[i] = views.TableColResizedWithWindow
+		}
+	}
+	return widths
+}
+
+// alignments returns the alignments of the columns.
+func (cs columns) alignments() []tui.Alignment {
+	alignments := make([]tui.Alignment, len(cs))
+	for i, c := range cs {
+		alignments[i] = c.align
+	}
+	return alignments
+}
+
+// fields returns the cells of the columns in the filter,
+// keyed by the column names and aliases.
+func (cs columns) fields() map[string]xtui.Cell {
+	fields := make(map[string]xtui.Cell)
+	for i, c := range cs {
+		fields[c.name] = xtui.Cell{Row: 0, Column: i}
+		if c.alias != "" {
+			fields[c.alias] = xtui.Cell{Row: 0, Column: i}
+		}
+	}
+	return fields
+}
+
+// keys returns the indexes of the key columns.
+func (cs columns) keys() []int {
+	var keys []int
+	for i, c := range cs {
+		if c.key {
+			keys = append(keys, i)
+		}
+	}
+	return keys
+}
+
+// rows formats the entries, which must be a slice, to table rows
+// with the raw values.
+func (cs columns) rows(entries interface{}) xtui.TableRows {
+	return cs.formatRows(entries, units{})
+}
+
+// entryValues returns the entries, which must be a slice,
+// as the values of the table entries.
+func entryValues(entries interface{}) []interface{} {
+	list := reflect.ValueOf(entries)
+	values := make([]interface{}, list.Len())
+	for i := range values {
+		values[i] = list.Index(i).Interface()
+	}
+	return values
+}
+
+// formatRows formats the entries, which must be a slice, to table
+// rows with the values with a unit formatted by the units.
+func (cs columns) formatRows(entries interface{}, u units) xtui.TableRows {
+	list := reflect.ValueOf(entries)
+	rows := make(xtui.TableRows, list.Len())
+	for i := range rows {
+		entry := list.Index(i).Interface()
+		rows[i] = make([]string, len(cs))
+		for j, c := range cs {
+			rows[i][j] = c.formatValue(c.value(entry), u)
+		}
+	}
+	return rows
+}
+
+// layoutRows formats the entries, which must be a slice, to the layout
+// with rowsPerEntry rows of cells cells per entry. The values of the
+// columns are placed in their cells, joined by "/" if they share one,
+// with their labels in the cells on the left.
+func (cs columns) layoutRows(entries interface{}, rowsPerEntry, cells int, u units) xtui.TableRows {
+	list := reflect.ValueOf(entries)
+	rows := make(xtui.TableRows, rowsPerEntry*list.Len())
+	for i := range rows {
+		rows[i] = make([]string, cells)
+	}
+	for i := 0; i < list.Len(); i++ {
+		entry := list.Index(i).Interface()
+		placed := make(map[xtui.Cell]bool)
+		for j := range cs {
+			c := &cs[j]
+			if c.cell == nil {
+				continue
+			}
+			row := rows[rowsPerEntry*i+c.cell.Row]
+			if placed[*c.cell] {
+				row[c.cell.Column] += "/"
+			}
+			row[c.cell.Column] += c.formatValue(c.value(entry), u)
+			placed[*c.cell] = true
+			if c.label != "" {
+				row[c.cell.Column-1] = c.label
+			}
+		}
+	}
+	return rows
+}
+
+// layoutFields returns the cells of the columns in the layout
+// in the filter, keyed by the column names and aliases.
+func (cs columns) layoutFields() map[string]xtui.Cell {
+	fields := make(map[string]xtui.Cell)
+	for _, c := range cs {
+		if c.cell == nil {
+			continue
+		}
+		fields[c.name] = *c.cell
+		if c.alias != "" {
+			fields[c.alias] = *c.cell
+		}
+	}
+	return fields
+}
+
+// layoutSortItems returns the index of the column sorted by each of
+// the cells of the first row of the layout, or NoColumn if there is none.
+func (cs columns) layoutSortItems(cells int) []int {
+	items := make([]int, cells)
+	for i := range items {
+		items[i] = NoColumn
+	}
+	for i := len(cs) - 1; i >= 0; i-- {
+		if c := cs[i].cell; c != nil && c.Row == 0 && c.Column < cells {
+			items[c.Column] = i
+		}
+	}
+	return items
+}
+
+// at returns the cell at the row and column of an entry.
+func at(row, column int) *xtui.Cell {
+	return &xtui.Cell{Row: row, Column: column}
+}
+
+// by returns the sort order with the field as the primary key, the
+// previous keys follow it. If the field already is the primary key,
+// its order is reversed. A new primary key is sorted descending.
+func (o sortOrder) by(field int) sortOrder {
+	if len(o) != 0 && o[0].field == field {
+		order := append(sortOrder(nil), o...)
+		order[0].asc = !order[0].asc
+		return order
+	}
+	order := sortOrder{{field: field, asc: false}}
+	for _, key := range o {
+		if key.field != field && len(order) < maxSortKeys {
+			order = append(order, key)
+		}
+	}
+	return order
+}
+
+// sort sorts the entries, which must be a slice, in the sort order.
+// The sort is stable, so the entries with equal keys keep their order.
+func (cs columns) sort(entries interface{}, order sortOrder) {
+	var keys []column
+	var asc []bool
+	for _, key := range order {
+		if key.field < 0 || key.field >= len(cs) {
+			continue
+		}
+		keys = append(keys, cs[key.field])
+		asc = append(asc, key.asc)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	list := reflect.ValueOf(entries)
+	s := &sorter{
+		values: make([][]interface{}, list.Len()),
+		swap:   reflect.Swapper(entries),
+		less:   make([]func(a, b interface{}) bool, len(keys)),
+		asc:    asc,
+	}
+	for i, key := range keys {
+		s.less[i] = key.less
+		if s.less[i] == nil {
+			s.less[i] = less
+		}
+	}
+	for i := range s.values {
+		entry := list.Index(i).Interface()
+		s.values[i] = make([]interface{}, len(keys))
+		for j, key := range keys {
+			s.values[i][j] = key.value(entry)
+		}
+	}
+	sort.Stable(s)
+}
+
+// formatValue formats the value of the column. The numbers with
+// a unit are formatted by the units, unless they are raw.
+func (c *column) formatValue(v interface{}, u units) string {
+	if c.unit != "" && (u.human || c.unit == unitBps && u.byteRates) {
+		switch n := v.(type) {
+		case uint64:
+			return u.formatUint(n, c.unit)
+		case float64:
+			return u.format(n, c.unit)
+		}
+	}
+	if c.format != nil {
+		return c.format(v)
+	}
+	if f, ok := v.(float64); ok {
+		return fmt.Sprintf("%.2f", f)
+	}
+	return fmt.Sprint(v)
+}
+
+// sorter sorts the entries by the values of the sort keys
+// extracted from them.
+type sorter struct {
+	values [][]interface{}
+	swap   func(i, j int)
+	less   []func(a, b interface{}) bool
+	asc    []bool
+}
+
+func (s *sorter) Len() int { return len(s.values) }
+
+func (s *sorter) Less(i, j int) bool {
+	for k, less := range s.less {
+		a, b := s.values[i][k], s.values[j][k]
+		if !s.asc[k] {
+			a, b = b, a
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+	}
+	return false
+}
+
+func (s *sorter) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.swap(i, j)
+}
+
+// less compares two values of the same type.
+func less(a, b interface{}) bool {
+	switch a := a.(type) {
+	case uint64:
+		return a < b.(uint64)
+	case float64:
+		return a < b.(float64)
+	case string:
+		return naturalLess(a, b.(string))
+	}
+	return false
+}
+
+// naturalLess compares the strings with the runs of digits compared
+// by their numeric value, so "eth0/8/0" is less than "eth0/10/0".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			da, db := digits(a), digits(b)
+			// compare the numbers without leading zeros by
+			// their length first, then by their digits.
+			na, nb := strings.TrimLeft(a[:da], "0"), strings.TrimLeft(b[:db], "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			if da != db {
+				return da < db
+			}
+			a, b = a[da:], b[db:]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// digits returns the length of the run of digits at the start of s.
+func digits(s string) int {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// formatUint formats the float value as an integer.
+func formatUint(v interface{}) string {
+	return fmt.Sprint(uint64(v.(float64)))
+}
+
+// mtu returns the MTU at index, or 0 if not present.
+func mtu(iface stats.Interface, i int) uint64 {
+	if i < len(iface.MTU) {
+		return uint64(iface.MTU[i])
+	}
+	return 0
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+func TestColumns_Sort(t *testing.T) {
+	nodes := []nodeTrend{
+		{Node: stats.Node{Name: "b", Index: 1, Clocks: 3}},
+		{Node: stats.Node{Name: "c", Index: 2, Clocks: 1}},
+		{Node: stats.Node{Name: "a", Index: 3, Clocks: 2}},
+	}
+	nodes = append(nodes, nodeTrend{Node: stats.Node{Name: "d", Index: 4, Clocks: 2}})
+	name, clocks, index := nodeColumns.index("NodeName"), nodeColumns.index("Clocks"), nodeColumns.index("NodeIndex")
+	tests := []struct {
+		order sortOrder
+		want  []string
+	}{
+		{order: sortOrder{{field: name, asc: true}}, want: []string{"a", "b", "c", "d"}},
+		{order: sortOrder{{field: name, asc: false}}, want: []string{"d", "c", "b", "a"}},
+		{order: sortOrder{{field: index, asc: false}}, want: []string{"d", "a", "c", "b"}},
+		{order: sortOrder{{field: clocks, asc: true}, {field: name, asc: false}}, want: []string{"c", "d", "a", "b"}},
+		{order: sortOrder{{field: clocks, asc: false}, {field: name, asc: true}}, want: []string{"b", "a", "d", "c"}},
+	}
+	for _, test := range tests {
+		nodeColumns.sort(nodes, test.order)
+		var got []string
+		for _, node := range nodes {
+			got = append(got, node.Name)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestCompactIfaceColumns_Sort(t *testing.T) {
+	// the compact layout sorts by the interface columns.
+	for _, name := range compactIfaceColumns.names() {
+		if ifaceColumns.index(name) == NoColumn && name != "RxTrend" && name != "TxTrend" {
+			t.Errorf("Error occured got:%v; want:%v", name, "an interface column")
+		}
+	}
+
+	ifaces := []ifaceRates{
+		{Interface: stats.Interface{InterfaceCounters: stats.InterfaceCounters{InterfaceName: "eth0"}}, RxBps: 10},
+		{Interface: stats.Interface{InterfaceCounters: stats.InterfaceCounters{InterfaceName: "eth1"}}, RxBps: 30},
+		{Interface: stats.Interface{InterfaceCounters: stats.InterfaceCounters{InterfaceName: "eth2"}}, RxBps: 20},
+	}
+	ifaceColumns.sort(ifaces, sortOrder{{field: ifaceColumns.index("RxBps"), asc: false}})
+	var got []string
+	for _, iface := range ifaces {
+		got = append(got, iface.InterfaceName)
+	}
+	if want := []string{"eth1", "eth2", "eth0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestColumns_GroupBy(t *testing.T) {
+	g := compactIfaceColumns.groupBy(units{human: true}, "Name", parentIface, "RxBps")
+	// the sum of the formatted 1.4 Mbps would be 2.8 Mbps.
+	entries := []interface{}{ifaceRates{RxBps: 1.44e6}, ifaceRates{RxBps: 1.44e6}}
+	cells := g.Aggregate(entries)
+	if got, want := cells[xtui.Cell{Row: 0, Column: compactIfaceColumns.index("RxBps")}], "2.9 Mbps"; got != want {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestColumns_Rows(t *testing.T) {
+	nodes := []nodeTrend{{Node: stats.Node{Name: "ip4-input", Index: 7, Clocks: 12.7, Vectors: 2, Calls: 1, VectorsPerCall: 2}, Trend: "▁█"}}
+	want := xtui.TableRows{{"ip4-input", "7", "12", "2", "1", "0", "2.00", "▁█"}}
+	if got := nodeColumns.rows(nodes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestColumns_Layout(t *testing.T) {
+	cs := columns{
+		{name: "Name", value: func(e interface{}) interface{} { return e.(stats.Node).Name }, cell: at(0, 0)},
+		{name: "Calls", alias: "calls", value: func(e interface{}) interface{} { return e.(stats.Node).Calls }, cell: at(1, 2), label: "Counts"},
+		{name: "Vectors", value: func(e interface{}) interface{} { return e.(stats.Node).Vectors }, cell: at(1, 2)},
+		{name: "Clocks", value: func(e interface{}) interface{} { return e.(stats.Node).Clocks }},
+		{name: "Index", value: func(e interface{}) interface{} { return uint64(e.(stats.Node).Index) }, cell: at(0, 2)},
+	}
+	nodes := []stats.Node{{Name: "a", Index: 1, Calls: 2, Vectors: 3}, {Name: "b"}}
+
+	rows := cs.layoutRows(nodes, 2, 3, units{})
+	want := xtui.TableRows{{"a", "", "1"}, {"", "Counts", "2/3"}, {"b", "", "0"}, {"", "Counts", "0/0"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Error occured got:%v; want:%v", rows, want)
+	}
+	fields := map[string]xtui.Cell{"Name": {Row: 0, Column: 0}, "Calls": {Row: 1, Column: 2}, "calls": {Row: 1, Column: 2}, "Vectors": {Row: 1, Column: 2}, "Index": {Row: 0, Column: 2}}
+	if got := cs.layoutFields(); !reflect.DeepEqual(got, fields) {
+		t.Errorf("Error occured got:%v; want:%v", got, fields)
+	}
+	if got, want := cs.layoutSortItems(3), []int{0, NoColumn, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestIfaceColumns_Layout(t *testing.T) {
+	// the values and labels of the interface columns
+	// fit in the detailed layout and don't overlap.
+	labels := make(map[xtui.Cell]string)
+	for _, c := range ifaceColumns {
+		if c.cell != nil && c.label != "" {
+			labels[xtui.Cell{Row: c.cell.Row, Column: c.cell.Column - 1}] = c.name
+		}
+	}
+	for _, c := range ifaceColumns {
+		if c.cell == nil {
+			continue
+		}
+		if c.cell.Row >= RowsPerIface || c.cell.Column >= len(ifaceTable) || c.label != "" && c.cell.Column == 0 {
+			t.Errorf("Error occured got:%v; want:%v", *c.cell, "a cell of the layout")
+		}
+		if name, ok := labels[*c.cell]; ok {
+			t.Errorf("Error occured got:%v; want:%v", c.name, "not under the label of "+name)
+		}
+	}
+}
+
+func TestSortOrder_By(t *testing.T) {
+	var order sortOrder
+	order = order.by(1)
+	order = order.by(2)
+	order = order.by(3)
+	order = order.by(4)
+	want := sortOrder{{field: 4}, {field: 3}, {field: 2}}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Error occured got:%v; want:%v", order, want)
+	}
+	order = order.by(4)
+	want[0].asc = true
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Error occured got:%v; want:%v", order, want)
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "GigabitEthernet0/8/0", b: "GigabitEthernet0/10/0", want: true},
+		{a: "GigabitEthernet0/10/0", b: "GigabitEthernet0/8/0", want: false},
+		{a: "loop2", b: "loop10", want: true},
+		{a: "loop", b: "loop0", want: true},
+		{a: "a01", b: "a1", want: false},
+		{a: "a1", b: "a01", want: true},
+		{a: "ip4-input", b: "ip6-input", want: true},
+		{a: "x", b: "x", want: false},
+	}
+	for _, test := range tests {
+		if got := naturalLess(test.a, test.b); got != test.want {
+			t.Errorf("Error occured %q < %q got:%v; want:%v", test.a, test.b, got, test.want)
+		}
+	}
+}
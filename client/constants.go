@@ -19,57 +19,6 @@ package client
 // NoColumn indicates no column should be used.
 const NoColumn = -1
 
-// Mapped node stats fields
-const (
-	NodeStatNodeName = iota
-	NodeStatNodeIndex
-	NodeStatNodeClocks
-	NodeStatNodeVectors
-	NodeStatNodeCalls
-	NodeStatNodeSuspends
-	NodeStatNodeVC
-)
-
-// Mapped interface stats fields
-const (
-	IfaceStatIfaceName = iota
-	IfaceStatIfaceIdx
-	IfaceStatIfaceState
-	IfaceStatIfaceMTUL3
-	IfaceStatIfaceMTUIP4
-	IfaceStatIfaceMTUIP6
-	IfaceStatIfaceMTUMPLS
-	IfaceStatIfaceRxPackets
-	IfaceStatIfaceRxBytes
-	IfaceStatIfaceRxErrors
-	IfaceStatIfaceRxUnicastPackets
-	IfaceStatIfaceRxUnicastBytes
-	IfaceStatIfaceRxMulticastPackets
-	IfaceStatIfaceRxMulticastBytes
-	IfaceStatIfaceRxBroadcastPackets
-	IfaceStatIfaceRxBroadcastBytes
-	IfaceStatIfaceTxPackets
-	IfaceStatIfaceTxBytes
-	IfaceStatIfaceTxErrors
-	IfaceStatIfaceTxUnicastMissPackets
-	IfaceStatIfaceTxUnicastMissBytes
-	IfaceStatIfaceTxMulticastPackets
-	IfaceStatIfaceTxMulticastBytes
-	IfaceStatIfaceTxBroadcastPackets
-	IfaceStatIfaceTxBroadcastBytes
-	IfaceStatIfaceDrops
-	IfaceStatIfacePunts
-	IfaceStatIfaceIP4
-	IfaceStatIfaceIP6
-)
-
-// Mapped error stats fields.
-const (
-	ErrorStatErrorCounter = iota
-	ErrorStatErrorNodeName
-	ErrorStatErrorReason
-)
-
 const (
 	MemoryStatName = iota
 	MemoryStatID
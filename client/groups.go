@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"strings"
+
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+)
+
+// groupBy returns the grouping of the entries by the value of the
+// column with the name, summing up the values of the columns with
+// the sum names formatted by the units.
+func (cs columns) groupBy(u units, name string, group func(value string) string, sums ...string) *xtui.Grouping {
+	column := cs.index(name)
+	var summed []int
+	for _, sum := range sums {
+		if i := cs.index(sum); i != NoColumn {
+			summed = append(summed, i)
+		}
+	}
+	return &xtui.Grouping{
+		Column: column,
+		Group: func(entry xtui.TableRows) string {
+			if len(entry) == 0 || column >= len(entry[0]) {
+				return ""
+			}
+			return group(strings.TrimSpace(entry[0][column]))
+		},
+		Aggregate: func(entries []interface{}) map[xtui.Cell]string {
+			cells := make(map[xtui.Cell]string, len(summed))
+			for _, i := range summed {
+				if sum, ok := cs[i].sum(entries); ok {
+					cells[xtui.Cell{Row: 0, Column: i}] = cs[i].formatValue(sum, u)
+				}
+			}
+			return cells
+		},
+	}
+}
+
+// sum returns the sum of the values of the column of the entries,
+// false if an entry is missing or its value is not a number.
+func (c *column) sum(entries []interface{}) (interface{}, bool) {
+	var ints uint64
+	var floats float64
+	isFloat := false
+	for _, e := range entries {
+		if e == nil {
+			return nil, false
+		}
+		switch v := c.value(e).(type) {
+		case uint64:
+			ints += v
+		case float64:
+			floats += v
+			isFloat = true
+		default:
+			return nil, false
+		}
+	}
+	if isFloat {
+		return floats, true
+	}
+	return ints, true
+}
+
+// nodeArc returns the group of the node, which is the prefix of its
+// name before the first dash, e.g. ip4-* for ip4-lookup.
+func nodeArc(name string) string {
+	i := strings.IndexByte(name, '-')
+	if i <= 0 {
+		return ""
+	}
+	return name[:i] + "-*"
+}
+
+// parentIface returns the name of the parent
+// interface of a sub-interface, e.g. eth0 for eth0.100.
+func parentIface(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if i <= 0 {
+		return ""
+	}
+	return name[:i]
+}
+
+// sameValue groups the entries with the same value.
+func sameValue(value string) string {
+	return value
+}
+
+// ifaceSums are the interface columns summed up in the group
+// headers of the interfaces, displayed in their cells.
+var ifaceSums = []string{"RxPackets", "TxPackets", "Drops", "Punts", "RxPps", "TxPps", "RxBytes", "TxBytes", "RxErrors", "TxErrors"}
+
+// groupings returns the groupings of the tabs and the compact
+// interfaces, with the sums formatted by the units.
+func groupings(u units) (tabs []*xtui.Grouping, compact *xtui.Grouping) {
+	ifaces := &xtui.Grouping{
+		Column: 0,
+		Group: func(entry xtui.TableRows) string {
+			if len(entry) == 0 || len(entry[0]) == 0 {
+				return ""
+			}
+			return parentIface(entry[0][0])
+		},
+		Aggregate: func(entries []interface{}) map[xtui.Cell]string {
+			cells := make(map[xtui.Cell]string, len(ifaceSums))
+			for _, name := range ifaceSums {
+				c := &ifaceColumns[ifaceColumns.index(name)]
+				switch sum, _ := c.sum(entries); v := sum.(type) {
+				case uint64:
+					cells[*c.cell] = u.formatUint(v, c.unit)
+				case float64:
+					cells[*c.cell] = u.format(v, c.unit)
+				}
+			}
+			return cells
+		},
+	}
+
+	tabs = []*xtui.Grouping{
+		Interfaces: ifaces,
+		Nodes:      nodeColumns.groupBy(u, "NodeName", nodeArc, "Vectors", "Calls", "Suspends"),
+		Errors:     errorColumns.groupBy(u, "Node", sameValue, "Counter"),
+		Memory:     nil,
+		Threads:    nil,
+	}
+	compact = compactIfaceColumns.groupBy(u, "Name", parentIface, "RxPps", "TxPps", "RxBps", "TxBps", "Drops", "Errors")
+	return tabs, compact
+}
+
+// setGroup groups or ungroups the entries of the tab.
+func (app *App) setGroup(tab int, on bool) {
+	tabs, compact := groupings(app.getUnits())
+	if tabs[tab] == nil {
+		return
+	}
+	app.tabLock.Lock()
+	app.grouped[tab] = on
+	app.tabLock.Unlock()
+
+	if !on {
+		tabs[tab], compact = nil, nil
+	}
+	app.tables[tab].SetGrouping(tabs[tab])
+	if tab == Interfaces {
+		app.compactIfaces.SetGrouping(compact)
+	}
+}
+
+// isGrouped returns true if the entries of the tab are grouped.
+func (app *App) isGrouped(tab int) bool {
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	return app.grouped[tab]
+}
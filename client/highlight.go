@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"log"
+	"strings"
+
+	"github.com/PantheonTechnologies/vpptop/gui"
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+	tui "github.com/gizak/termui/v3"
+)
+
+// errorFields are the fields of the error counters,
+// which are highlighted when they increase.
+var errorFields = []string{"Counter", "Drops", "Errors", "RxErrors", "TxErrors", "RxNoBuf", "RxMiss"}
+
+// trendFields are the fields of the sparklines, which
+// change on every poll and are never highlighted.
+var trendFields = []string{"Trend", "RxTrend", "TxTrend"}
+
+// highlighter returns the highlighter of the table with the fields. The values
+// over their threshold, keyed by the field name, are highlighted first, then
+// the error counters which increased and then the other changed values.
+func highlighter(fields map[string]xtui.Cell, thresholds map[string]float64) xtui.Highlighter {
+	errorCells := make(map[xtui.Cell]bool)
+	for _, name := range errorFields {
+		if cell, ok := field(fields, name); ok {
+			errorCells[cell] = true
+		}
+	}
+	trendCells := make(map[xtui.Cell]bool)
+	for _, name := range trendFields {
+		if cell, ok := field(fields, name); ok {
+			trendCells[cell] = true
+		}
+	}
+	limits := make(map[xtui.Cell]float64)
+	for name, limit := range thresholds {
+		cell, ok := field(fields, name)
+		if !ok {
+			log.Printf("unknown threshold column %q\n", name)
+			continue
+		}
+		limits[cell] = limit
+	}
+
+	return func(cell xtui.Cell, value string, change xtui.Change) (tui.Style, bool) {
+		if trendCells[cell] {
+			return tui.Style{}, false
+		}
+		theme := gui.CurrentTheme()
+		if limit, ok := limits[cell]; ok {
+			if v, ok := xtui.ParseNumber(value); ok && v > limit {
+				return theme.OverThreshold, true
+			}
+		}
+		switch {
+		case change == xtui.Increased && errorCells[cell]:
+			return theme.Increased, true
+		case change != xtui.Unchanged:
+			return theme.Changed, true
+		}
+		return tui.Style{}, false
+	}
+}
+
+// field returns the cell of the field with the name, case-insensitive.
+func field(fields map[string]xtui.Cell, name string) (xtui.Cell, bool) {
+	for n, cell := range fields {
+		if strings.EqualFold(n, name) {
+			return cell, true
+		}
+	}
+	return xtui.Cell{}, false
+}
+
+// setHighlight turns the highlighting of the changed cells on or off.
+func (app *App) setHighlight(on bool) {
+	app.tabLock.Lock()
+	app.highlight = on
+	app.tabLock.Unlock()
+
+	fields := []map[string]xtui.Cell{
+		Interfaces: ifaceColumns.layoutFields(),
+		Nodes:      nodeColumns.fields(),
+		Errors:     errorColumns.fields(),
+		Memory:     nil,
+		Threads:    threadColumns.fields(),
+	}
+	for tab, name := range tabNames {
+		var h xtui.Highlighter
+		if on {
+			h = highlighter(fields[tab], app.cfg.Tab(name).Thresholds)
+		}
+		app.tables[tab].SetHighlighter(h)
+		if tab == Interfaces {
+			if on {
+				h = highlighter(compactIfaceColumns.fields(), app.cfg.Tab(name).Thresholds)
+			}
+			app.compactIfaces.SetHighlighter(h)
+		}
+	}
+}
+
+// isHighlight returns true if the changed cells are highlighted.
+func (app *App) isHighlight() bool {
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	return app.highlight
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/stats"
+	tui "github.com/gizak/termui/v3"
+)
+
+const (
+	// sparklineWidth is the number of samples displayed in a sparkline.
+	sparklineWidth = 20
+	// gapIntervals is the number of intervals between two samples
+	// after which the series is broken, e.g. when the tab was not
+	// polled for a while, and starts again.
+	gapIntervals = 2
+)
+
+// point is a sample of a metric and the time it was taken.
+type point struct {
+	at    time.Time
+	value float64
+}
+
+// ring is a bounded series of samples, the oldest
+// sample is overwritten when the ring is full.
+type ring struct {
+	samples []point
+	next    int
+	full    bool
+}
+
+func newRing(size int) *ring {
+	return &ring{samples: make([]point, size)}
+}
+
+// add appends the sample to the ring.
+func (r *ring) add(p point) {
+	r.samples[r.next] = p
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// last returns the latest sample, false if there is none.
+func (r *ring) last() (point, bool) {
+	if !r.full && r.next == 0 {
+		return point{}, false
+	}
+	return r.samples[(r.next+len(r.samples)-1)%len(r.samples)], true
+}
+
+// reset forgets the samples.
+func (r *ring) reset() {
+	r.next, r.full = 0, false
+}
+
+// points returns the samples from the oldest one.
+func (r *ring) points() []point {
+	if !r.full {
+		return append([]point(nil), r.samples[:r.next]...)
+	}
+	return append(append([]point(nil), r.samples[r.next:]...), r.samples[:r.next]...)
+}
+
+// sample is the value of each metric of an entity in a single poll.
+type sample map[string]float64
+
+// history keeps the recent samples of the metrics of the entities,
+// e.g. the counters of the interfaces keyed by the interface name.
+type history struct {
+	sync.Mutex
+	size     int
+	interval time.Duration
+	series   map[string]map[string]*ring
+}
+
+// newHistory returns a history keeping the samples of the window,
+// taken at the interval.
+func newHistory(window, interval time.Duration) *history {
+	return &history{
+		size:     historySize(window, interval),
+		interval: interval,
+		series:   make(map[string]map[string]*ring),
+	}
+}
+
+// historySize returns the number of samples of the window.
+func historySize(window, interval time.Duration) int {
+	if interval <= 0 || window < interval {
+		return 1
+	}
+	return int(window / interval)
+}
+
+// record adds the samples of the entities from a single poll taken at
+// the time, keyed by the entity. The entities missing in the poll are
+// forgotten. A series not sampled for gapIntervals starts again.
+func (h *history) record(at time.Time, samples map[string]sample) {
+	h.Lock()
+	defer h.Unlock()
+
+	for entity := range h.series {
+		if _, ok := samples[entity]; !ok {
+			delete(h.series, entity)
+		}
+	}
+	for entity, metrics := range samples {
+		series, ok := h.series[entity]
+		if !ok {
+			series = make(map[string]*ring)
+			h.series[entity] = series
+		}
+		for metric, v := range metrics {
+			r, ok := series[metric]
+			if !ok {
+				r = newRing(h.size)
+				series[metric] = r
+			}
+			if last, ok := r.last(); ok && at.Sub(last.at) > gapIntervals*h.interval {
+				r.reset()
+			}
+			r.add(point{at: at, value: v})
+		}
+	}
+}
+
+// last returns the latest sample of the metric of the entity, false
+// if there is none or it is older than gapIntervals at now, e.g. when
+// the tab was not polled for a while.
+func (h *history) last(entity, metric string, now time.Time) (point, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	if r, ok := h.series[entity][metric]; ok {
+		if last, ok := r.last(); ok && now.Sub(last.at) <= gapIntervals*h.interval {
+			return last, true
+		}
+	}
+	return point{}, false
+}
+
+// points returns the samples of the metric of the entity from the oldest one.
+func (h *history) points(entity, metric string) []point {
+	h.Lock()
+	defer h.Unlock()
+
+	if r, ok := h.series[entity][metric]; ok {
+		return r.points()
+	}
+	return nil
+}
+
+// values returns the values of the samples of the metric
+// of the entity from the oldest one.
+func (h *history) values(entity, metric string) []float64 {
+	points := h.points(entity, metric)
+	if points == nil {
+		return nil
+	}
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.value
+	}
+	return values
+}
+
+// rate returns the last increment of the counter of the entity per
+// second, false if there are less than two samples of the counter.
+func (h *history) rate(entity, metric string) (float64, bool) {
+	points := h.points(entity, metric)
+	if len(points) < 2 {
+		return 0, false
+	}
+	return rates(points[len(points)-2:])[0], true
+}
+
+// resize changes the number of kept samples, keeping the latest ones.
+func (h *history) resize(window, interval time.Duration) {
+	h.Lock()
+	defer h.Unlock()
+
+	size := historySize(window, interval)
+	h.interval = interval
+	if size == h.size {
+		return
+	}
+	h.size = size
+	for _, series := range h.series {
+		for metric, r := range series {
+			resized := newRing(size)
+			points := r.points()
+			if len(points) > size {
+				points = points[len(points)-size:]
+			}
+			for _, p := range points {
+				resized.add(p)
+			}
+			series[metric] = resized
+		}
+	}
+}
+
+// clear forgets all samples, e.g. after the counters were cleared.
+func (h *history) clear() {
+	h.Lock()
+	defer h.Unlock()
+	h.series = make(map[string]map[string]*ring)
+}
+
+// deltas returns the differences of the consecutive values of a counter,
+// a counter which decreased, e.g. after it was cleared, counts from 0.
+func deltas(values []float64) []float64 {
+	if len(values) < 2 {
+		return nil
+	}
+	d := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		d[i-1] = values[i] - values[i-1]
+		if d[i-1] < 0 {
+			d[i-1] = values[i]
+		}
+	}
+	return d
+}
+
+// rates returns the increments per second of the consecutive samples of
+// a counter, a counter which decreased, e.g. after it was cleared, counts
+// from 0.
+func rates(points []point) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.value
+	}
+	r := deltas(values)
+	for i := range r {
+		if secs := points[i+1].at.Sub(points[i].at).Seconds(); secs > 0 {
+			r[i] /= secs
+		}
+	}
+	return r
+}
+
+// sparkline draws the last width values as bars, scaled to the maximum
+// of the drawn values. The termui Sparkline is a widget of its own, it
+// can't be drawn into a table cell, so the sparkline is text of its bars.
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		// the lowest bar marks a sample, the space is not used.
+		level := 1
+		if max > 0 && v > 0 {
+			level = 1 + int(v/max*float64(len(tui.BARS)-2)+0.5)
+		}
+		bars[i] = tui.BARS[level]
+	}
+	return string(bars)
+}
+
+// recordInterfaces records the counters of the interfaces.
+func (app *App) recordInterfaces(ifaces []stats.Interface) {
+	samples := make(map[string]sample, len(ifaces))
+	for _, iface := range ifaces {
+		samples[iface.InterfaceName] = sample{
+			"RxPackets": float64(iface.Rx.Packets),
+			"TxPackets": float64(iface.Tx.Packets),
+			"RxBytes":   float64(iface.Rx.Bytes),
+			"TxBytes":   float64(iface.Tx.Bytes),
+			"Drops":     float64(iface.Drops),
+			"RxMiss":    float64(iface.RxMiss),
+		}
+	}
+	app.ifaceHistory.record(time.Now(), samples)
+}
+
+// ifaceTrend returns the sparkline of the rates of the interface counter.
+func (app *App) ifaceTrend(name, counter string) string {
+	return sparkline(rates(app.ifaceHistory.points(name, counter)), sparklineWidth)
+}
+
+// nodeKeys returns the keys of the nodes in the history. The nodes of
+// the worker threads share the name, so it is followed by its occurrence.
+func nodeKeys(nodes []stats.Node) []string {
+	keys := make([]string, len(nodes))
+	seen := make(map[string]int)
+	for i, node := range nodes {
+		keys[i] = fmt.Sprintf("%s#%d", node.Name, seen[node.Name])
+		seen[node.Name]++
+	}
+	return keys
+}
+
+// nodeTrends records the counters of the nodes and returns the nodes
+// with the sparklines of their clocks, idle if they were not called
+// since the previous sample.
+func (app *App) nodeTrends(nodes []stats.Node) []nodeTrend {
+	keys := nodeKeys(nodes)
+	trends := make([]nodeTrend, len(nodes))
+	samples := make(map[string]sample, len(nodes))
+	now := time.Now()
+	for i, node := range nodes {
+		prev, known := app.nodeHistory.last(keys[i], "Calls", now)
+		trends[i].idle = idle(float64(counterDelta(node.Calls, uint64(prev.value))), known, node.Calls)
+		samples[keys[i]] = sample{
+			"Clocks":        node.Clocks,
+			"Vectors":       float64(node.Vectors),
+			"Calls":         float64(node.Calls),
+			"Vectors/Calls": node.VectorsPerCall,
+		}
+	}
+	app.nodeHistory.record(now, samples)
+
+	for i, node := range nodes {
+		trends[i].Node = node
+		trends[i].Trend = sparkline(app.nodeHistory.values(keys[i], "Clocks"), sparklineWidth)
+	}
+	return trends
+}
+
+// errorKey returns the key of the error counter in the history.
+func errorKey(e stats.Error) string {
+	return e.Node + "/" + e.Name
+}
+
+// errorTrends records the error counters and returns them with the
+// sparklines of their rates, idle if they did not increase since the
+// previous sample.
+func (app *App) errorTrends(errors []stats.Error) []errorTrend {
+	trends := make([]errorTrend, len(errors))
+	samples := make(map[string]sample, len(errors))
+	now := time.Now()
+	for i, e := range errors {
+		prev, known := app.errorHistory.last(errorKey(e), "Counter", now)
+		trends[i].idle = idle(float64(counterDelta(e.Value, uint64(prev.value))), known, e.Value)
+		samples[errorKey(e)] = sample{"Counter": float64(e.Value)}
+	}
+	app.errorHistory.record(now, samples)
+
+	for i, e := range errors {
+		trends[i].Error = e
+		trends[i].Trend = sparkline(rates(app.errorHistory.points(errorKey(e), "Counter")), sparklineWidth)
+	}
+	return trends
+}
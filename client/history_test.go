@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistory_Record(t *testing.T) {
+	h := newHistory(3*time.Second, time.Second)
+	start := time.Now()
+	for i := 1; i <= 4; i++ {
+		h.record(start.Add(time.Duration(i)*time.Second), map[string]sample{"eth0": {"RxPackets": float64(i)}})
+	}
+	if got, want := h.values("eth0", "RxPackets"), []float64{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+
+	h.resize(2*time.Second, time.Second)
+	if got, want := h.values("eth0", "RxPackets"), []float64{3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+
+	// the interfaces missing in the poll are forgotten.
+	h.record(start.Add(5*time.Second), map[string]sample{"eth1": {"RxPackets": 1}})
+	if got := h.values("eth0", "RxPackets"); got != nil {
+		t.Errorf("Error occured got:%v; want:%v", got, nil)
+	}
+}
+
+func TestHistory_Rate(t *testing.T) {
+	h := newHistory(time.Minute, time.Second)
+	start := time.Now()
+	h.record(start, map[string]sample{"eth0": {"RxPackets": 100}})
+	h.record(start.Add(time.Second), map[string]sample{"eth0": {"RxPackets": 200}})
+	h.record(start.Add(3*time.Second/2), map[string]sample{"eth0": {"RxPackets": 300}})
+	if got, _ := h.rate("eth0", "RxPackets"); got != 200 {
+		t.Errorf("Error occured got:%v; want:%v", got, 200)
+	}
+
+	// the series starts again after a gap.
+	h.record(start.Add(time.Minute), map[string]sample{"eth0": {"RxPackets": 5000}})
+	if got, known := h.rate("eth0", "RxPackets"); known {
+		t.Errorf("Error occured got:%v; want:%v", got, "unknown")
+	}
+	if got, want := h.values("eth0", "RxPackets"), []float64{5000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestHistory_Last(t *testing.T) {
+	h := newHistory(time.Minute, time.Second)
+	start := time.Now()
+	h.record(start, map[string]sample{"eth0": {"RxPackets": 100}})
+	if got, known := h.last("eth0", "RxPackets", start.Add(2*time.Second)); !known || got.value != 100 {
+		t.Errorf("Error occured got:%v; want:%v", got.value, 100)
+	}
+	// the sample of a tab not polled for a while is missing.
+	if got, known := h.last("eth0", "RxPackets", start.Add(3*time.Second)); known {
+		t.Errorf("Error occured got:%v; want:%v", got.value, "missing")
+	}
+}
+
+func TestRateSeries(t *testing.T) {
+	start := time.Now()
+	points := []point{
+		{at: start, value: 0},
+		{at: start.Add(time.Second), value: 100},
+		{at: start.Add(3 * time.Second), value: 300},
+	}
+	s := rateSeries("rx", points, 8)
+	if want := []float64{800, 800}; !reflect.DeepEqual(s.Values, want) {
+		t.Errorf("Error occured got:%v; want:%v", s.Values, want)
+	}
+	if want := []time.Time{points[1].at, points[2].at}; !reflect.DeepEqual(s.Times, want) {
+		t.Errorf("Error occured got:%v; want:%v", s.Times, want)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		width  int
+		want   string
+	}{
+		{values: nil, width: 5, want: ""},
+		{values: []float64{0, 0}, width: 5, want: "▁▁"},
+		{values: []float64{0, 7, 14}, width: 5, want: "▁▅█"},
+		{values: []float64{14, 0, 7, 14}, width: 3, want: "▁▅█"},
+		// the counter was cleared.
+		{values: deltas([]float64{10, 20, 5}), width: 5, want: "█▅"},
+	}
+	for _, test := range tests {
+		if got := sparkline(test.values, test.width); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+)
+
+// hookRetryDelay is the delay before the first retry of a failed
+// alert hook invocation, it is doubled for each further retry.
+var hookRetryDelay = time.Second
+
+// alertPayload is the alert passed to the alert hooks.
+type alertPayload struct {
+	Alert     string    `json:"alert"`
+	Metric    string    `json:"metric"`
+	Tab       string    `json:"tab"`
+	Entity    string    `json:"entity"`
+	State     string    `json:"state"`
+	Severity  string    `json:"severity"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Time      time.Time `json:"time"`
+	Host      string    `json:"host"`
+}
+
+// newAlertPayload returns the payload of the alert event.
+func newAlertPayload(e alertEvent, host string) alertPayload {
+	threshold, _ := e.rule.Threshold()
+	state := "cleared"
+	if e.raised {
+		state = "raised"
+	}
+	return alertPayload{
+		Alert:     e.rule.Name,
+		Metric:    e.rule.Metric,
+		Tab:       strings.ToLower(tabNames[e.tab]),
+		Entity:    e.entity,
+		State:     state,
+		Severity:  e.rule.Severity,
+		Value:     e.value,
+		Threshold: threshold,
+		Time:      e.time,
+		Host:      host,
+	}
+}
+
+// env returns the payload as the environment variables of an exec hook.
+func (p alertPayload) env() []string {
+	return []string{
+		"VPPTOP_ALERT=" + p.Alert,
+		"VPPTOP_ALERT_METRIC=" + p.Metric,
+		"VPPTOP_ALERT_TAB=" + p.Tab,
+		"VPPTOP_ALERT_ENTITY=" + p.Entity,
+		"VPPTOP_ALERT_STATE=" + p.State,
+		"VPPTOP_ALERT_SEVERITY=" + p.Severity,
+		fmt.Sprintf("VPPTOP_ALERT_VALUE=%v", p.Value),
+		fmt.Sprintf("VPPTOP_ALERT_THRESHOLD=%v", p.Threshold),
+		"VPPTOP_ALERT_TIME=" + p.Time.Format(time.RFC3339),
+		"VPPTOP_ALERT_HOST=" + p.Host,
+	}
+}
+
+// alertHook invokes a configured hook, at most
+// RateLimit times in a minute.
+type alertHook struct {
+	config.AlertHook
+	sync.Mutex
+	// invoked are the times of the invocations in the last minute.
+	invoked []time.Time
+}
+
+// name returns the name of the hook used in the log.
+func (h *alertHook) name() string {
+	if h.URL != "" {
+		return h.URL
+	}
+	return h.Exec[0]
+}
+
+// matches returns true if the rule invokes the hook.
+func (h *alertHook) matches(rule string) bool {
+	if len(h.Rules) == 0 {
+		return true
+	}
+	for _, r := range h.Rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// allow returns true if the hook can be invoked at the time
+// without exceeding its rate limit, and records the invocation.
+func (h *alertHook) allow(now time.Time) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	recent := h.invoked[:0]
+	for _, t := range h.invoked {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	h.invoked = recent
+	if len(h.invoked) >= h.RateLimit {
+		return false
+	}
+	h.invoked = append(h.invoked, now)
+	return true
+}
+
+// run invokes the hook with the payload, retrying the failed
+// invocations until ctx is done. Each invocation is logged,
+// as well as the payload which was not delivered.
+func (h *alertHook) run(ctx context.Context, p alertPayload) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		log.Printf("alert hook %s: %v\n", h.name(), err)
+		return
+	}
+	delay := hookRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := h.invoke(ctx, body, p.env())
+		if err == nil {
+			log.Printf("alert hook %s: %s %s on %s delivered\n", h.name(), p.State, p.Alert, p.Entity)
+			return
+		}
+		if ctx.Err() != nil {
+			log.Printf("alert hook %s: %s %s on %s dropped, shutting down\n", h.name(), p.State, p.Alert, p.Entity)
+			return
+		}
+		log.Printf("alert hook %s: %s %s on %s attempt %d failed: %v\n", h.name(), p.State, p.Alert, p.Entity, attempt+1, err)
+		if attempt >= h.Retries {
+			log.Printf("alert hook %s: %s %s on %s dropped after %d attempts\n", h.name(), p.State, p.Alert, p.Entity, attempt+1)
+			return
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			log.Printf("alert hook %s: %s %s on %s dropped, shutting down\n", h.name(), p.State, p.Alert, p.Entity)
+			return
+		}
+		delay *= 2
+	}
+}
+
+// invoke runs the command or posts the body to the url.
+func (h *alertHook) invoke(ctx context.Context, body []byte, env []string) error {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	if h.URL == "" {
+		cmd := exec.CommandContext(ctx, h.Exec[0], h.Exec[1:]...)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdin = bytes.NewReader(body)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%v: %s", err, bytes.TrimSpace(out))
+		}
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// alertHooks invokes the hooks of the raised and cleared alerts.
+type alertHooks struct {
+	sync.Mutex
+	hooks []*alertHook
+	host  string
+	// ctx cancels the invocations in the background,
+	// which are tracked by wg.
+	ctx context.Context
+	wg  *sync.WaitGroup
+}
+
+// newAlertHooks returns the alert hooks of the configuration.
+func newAlertHooks(hooks []config.AlertHook) *alertHooks {
+	h := &alertHooks{ctx: context.Background(), wg: new(sync.WaitGroup)}
+	h.host, _ = os.Hostname()
+	h.set(hooks)
+	return h
+}
+
+// start runs the following invocations until ctx is done,
+// they are added to wg.
+func (h *alertHooks) start(ctx context.Context, wg *sync.WaitGroup) {
+	h.Lock()
+	defer h.Unlock()
+	h.ctx, h.wg = ctx, wg
+}
+
+// set replaces the hooks. The hooks which did not change keep
+// the invocations counted against their rate limit.
+func (h *alertHooks) set(hooks []config.AlertHook) {
+	h.Lock()
+	defer h.Unlock()
+
+	prev := h.hooks
+	h.hooks = make([]*alertHook, len(hooks))
+	for i := range hooks {
+		h.hooks[i] = &alertHook{AlertHook: hooks[i]}
+		for j, p := range prev {
+			if p != nil && reflect.DeepEqual(p.AlertHook, hooks[i]) {
+				h.hooks[i], prev[j] = p, nil
+				break
+			}
+		}
+	}
+}
+
+// notify invokes the hooks of the events in the background.
+// The events over the rate limit of a hook are dropped.
+func (h *alertHooks) notify(events []alertEvent) {
+	h.Lock()
+	defer h.Unlock()
+
+	for _, e := range events {
+		p := newAlertPayload(e, h.host)
+		for _, hook := range h.hooks {
+			if !hook.matches(e.rule.Name) {
+				continue
+			}
+			if !hook.allow(e.time) {
+				log.Printf("alert hook %s: %s %s on %s dropped, rate limit exceeded\n", hook.name(), p.State, p.Alert, p.Entity)
+				continue
+			}
+			h.wg.Add(1)
+			go func(hook *alertHook) {
+				defer h.wg.Done()
+				hook.run(h.ctx, p)
+			}(hook)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+)
+
+func TestAlertHook_Run(t *testing.T) {
+	hookRetryDelay = time.Millisecond
+	var requests []alertPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p alertPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("Error occured got:%v; want:%v", err, nil)
+		}
+		requests = append(requests, p)
+		// the first invocation fails and is retried.
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	above := 100.0
+	event := alertEvent{
+		rule:   config.AlertRule{Name: "drops", Metric: "interface.drops/s", Above: &above, Severity: config.SeverityCritical},
+		tab:    Interfaces,
+		entity: "eth0",
+		value:  150,
+		raised: true,
+	}
+	hook := &alertHook{AlertHook: config.AlertHook{URL: server.URL, Timeout: time.Second, Retries: 2}}
+	hook.run(context.Background(), newAlertPayload(event, "host"))
+
+	if len(requests) != 2 {
+		t.Fatalf("Error occured got:%v; want:%v", len(requests), 2)
+	}
+	want := alertPayload{
+		Alert: "drops", Metric: "interface.drops/s", Tab: "interfaces", Entity: "eth0", State: "raised",
+		Severity: config.SeverityCritical, Value: 150, Threshold: 100, Host: "host",
+	}
+	if got := requests[1]; got != want {
+		t.Errorf("Error occured got:%+v; want:%+v", got, want)
+	}
+}
+
+func TestAlertHook_Allow(t *testing.T) {
+	hook := &alertHook{AlertHook: config.AlertHook{RateLimit: 2}}
+	now := time.Now()
+	for i, want := range []bool{true, true, false} {
+		if got := hook.allow(now.Add(time.Duration(i) * time.Second)); got != want {
+			t.Errorf("Error occured got:%v; want:%v", got, want)
+		}
+	}
+	// the invocations older than a minute don't count.
+	if !hook.allow(now.Add(time.Minute)) {
+		t.Errorf("Error occured got:%v; want:%v", false, true)
+	}
+}
+
+func TestAlertHook_RunCancel(t *testing.T) {
+	hookRetryDelay = time.Hour
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	hook := &alertHook{AlertHook: config.AlertHook{URL: server.URL, Timeout: time.Second, Retries: 2}}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		hook.run(ctx, alertPayload{Alert: "drops"})
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("Error occured got:%v; want:%v", "retry wait", "cancelled")
+	}
+}
+
+func TestAlertHooks_Set(t *testing.T) {
+	hooks := []config.AlertHook{
+		{URL: "http://a", Rules: []string{"drops"}, RateLimit: 1},
+		{URL: "http://b", RateLimit: 1},
+	}
+	h := newAlertHooks(hooks)
+	now := time.Now()
+	for _, hook := range h.hooks {
+		hook.allow(now)
+	}
+
+	// the unchanged hook keeps its invocations on reload.
+	h.set([]config.AlertHook{hooks[0], {URL: "http://b", RateLimit: 2}})
+	for i, want := range []bool{false, true} {
+		if got := h.hooks[i].allow(now); got != want {
+			t.Errorf("Error occured got:%v; want:%v", got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import "time"
+
+// idleNotification is how long the toggled idle filter is shown.
+const idleNotification = 2 * time.Second
+
+// hasIdle returns true if the entries of the tab can be idle.
+func hasIdle(tab int) bool {
+	return tab == Interfaces || tab == Nodes || tab == Errors
+}
+
+// hideIdleDefault returns true if the idle entries of the tab
+// are hidden unless configured, which are the errors.
+func hideIdleDefault(tab int) bool {
+	return tab == Errors
+}
+
+// idle returns true if the counter did not increase since the previous
+// poll. Without a previous poll the counter is idle if it is 0.
+func idle(rate float64, known bool, total uint64) bool {
+	if known {
+		return rate == 0
+	}
+	return total == 0
+}
+
+// activeIfaces returns the interfaces which received or sent packets.
+func (app *App) activeIfaces(rates []ifaceRates) []ifaceRates {
+	active := make([]ifaceRates, 0, len(rates))
+	for _, r := range rates {
+		if !r.idle {
+			active = append(active, r)
+		}
+	}
+	return active
+}
+
+// activeNodes returns the nodes which were called.
+func (app *App) activeNodes(trends []nodeTrend) []nodeTrend {
+	active := make([]nodeTrend, 0, len(trends))
+	for _, t := range trends {
+		if !t.idle {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// activeErrors returns the error counters which increased.
+func (app *App) activeErrors(trends []errorTrend) []errorTrend {
+	active := make([]errorTrend, 0, len(trends))
+	for _, t := range trends {
+		if !t.idle {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// setHideIdle hides or shows the idle entries of the tab.
+func (app *App) setHideIdle(tab int, on bool) {
+	if !hasIdle(tab) {
+		return
+	}
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	app.hideIdle[tab] = on
+}
+
+// isHideIdle returns true if the idle entries of the tab are hidden.
+func (app *App) isHideIdle(tab int) bool {
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	return app.hideIdle[tab]
+}
+
+// toggleIdle hides or shows the idle entries of the tab.
+func (app *App) toggleIdle(tab int) {
+	if !hasIdle(tab) {
+		return
+	}
+	hide := !app.isHideIdle(tab)
+	app.setHideIdle(tab, hide)
+	if hide {
+		app.gui.Notify("idle entries hidden", idleNotification)
+	} else {
+		app.gui.Notify("idle entries shown", idleNotification)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+func TestIdle(t *testing.T) {
+	tests := []struct {
+		rate  float64
+		known bool
+		total uint64
+		want  bool
+	}{
+		{rate: 0, known: true, total: 10, want: true},
+		{rate: 5, known: true, total: 10, want: false},
+		{rate: 0, known: false, total: 0, want: true},
+		{rate: 0, known: false, total: 10, want: false},
+	}
+	for _, test := range tests {
+		if got := idle(test.rate, test.known, test.total); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestApp_ActiveErrors(t *testing.T) {
+	app := &App{cfg: config.Default(), errorHistory: newHistory(time.Minute, time.Second)}
+	errors := []stats.Error{
+		{Node: "ip4-input", Name: "ttl expired", Value: 10},
+		{Node: "ip4-input", Name: "bad checksum", Value: 5},
+		{Node: "ip4-input", Name: "options", Value: 0},
+	}
+	app.errorTrends(errors)
+	errors[0].Value = 12
+	trends := app.errorTrends(errors)
+
+	active := app.activeErrors(trends)
+	if len(active) != 1 || active[0].Name != "ttl expired" {
+		t.Errorf("Error occured got:%v; want:%v", active, "ttl expired")
+	}
+}
+
+func TestApp_ActiveIfaces(t *testing.T) {
+	app := &App{cfg: config.Default(), ifaceHistory: newHistory(time.Minute, time.Second), peaks: newPeakSampler()}
+	ifaces := []stats.Interface{
+		{InterfaceCounters: stats.InterfaceCounters{InterfaceName: "eth0", Rx: stats.InterfaceCounterCombined{Packets: 10}}},
+		{InterfaceCounters: stats.InterfaceCounters{InterfaceName: "eth1", Rx: stats.InterfaceCounterCombined{Packets: 10}}},
+	}
+	app.rates(append([]stats.Interface(nil), ifaces...))
+	app.ifCacheTime = time.Now().Add(-time.Second)
+	ifaces[0].Rx.Packets = 20
+	rates := app.rates(ifaces)
+
+	active := app.activeIfaces(rates)
+	if len(active) != 1 || active[0].InterfaceName != "eth0" {
+		t.Errorf("Error occured got:%v; want:%v", active, "eth0")
+	}
+}
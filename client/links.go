@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+// gaugeWidth is the number of characters of a utilization gauge.
+const gaugeWidth = 10
+
+// gaugeEighths are the partially filled characters of a gauge.
+var gaugeEighths = []rune(" ▏▎▍▌▋▊▉")
+
+// unknownUtil is the utilization of the interfaces
+// without a known link speed.
+const unknownUtil = -1
+
+// linkSpeed returns the speed of the link in bits per second,
+// the speed configured for the interface takes precedence.
+func (app *App) linkSpeed(iface stats.Interface) uint64 {
+	if speed, ok := app.cfg.LinkSpeed(iface.InterfaceName); ok {
+		return speed
+	}
+	return iface.LinkSpeed
+}
+
+// utilization returns the percentage of the link speed used by the
+// bits per second, unknownUtil if the speed is not known.
+func utilization(bps float64, speed uint64) float64 {
+	if speed == 0 {
+		return unknownUtil
+	}
+	return bps / float64(speed) * 100
+}
+
+// rates returns the interfaces with the rates per second computed from
+// the previous poll and the time elapsed since it, the previous poll is
+// replaced by the interfaces. The utilization of a half duplex link is
+// computed from both directions, as they share the link.
+func (app *App) rates(ifaces []stats.Interface) []ifaceRates {
+	now := time.Now()
+	secs := now.Sub(app.ifCacheTime).Seconds()
+	nameToIdx := make(map[string]int)
+	for i, iface := range app.IfCache {
+		nameToIdx[iface.InterfaceName] = i
+	}
+
+	// missed holds the interfaces of the previous poll,
+	// set if their rx-miss counter increased since then.
+	missed := make(map[string]bool)
+	rates := make([]ifaceRates, len(ifaces))
+	for i, iface := range ifaces {
+		rates[i].Interface = iface
+		idx, known := nameToIdx[iface.InterfaceName]
+		known = known && secs > 0
+		if known {
+			prev := app.IfCache[idx]
+			missed[iface.InterfaceName] = counterDelta(iface.RxMiss, prev.RxMiss) > 0
+			rates[i].RxPps = float64(counterDelta(iface.Rx.Packets, prev.Rx.Packets)) / secs
+			rates[i].TxPps = float64(counterDelta(iface.Tx.Packets, prev.Tx.Packets)) / secs
+			rates[i].RxBps = float64(8*counterDelta(iface.Rx.Bytes, prev.Rx.Bytes)) / secs
+			rates[i].TxBps = float64(8*counterDelta(iface.Tx.Bytes, prev.Tx.Bytes)) / secs
+		}
+		rates[i].idle = idle(rates[i].RxPps+rates[i].TxPps, known, iface.Rx.Packets+iface.Tx.Packets)
+		rates[i].Speed = app.linkSpeed(iface)
+		rxBits, txBits := rates[i].RxBps, rates[i].TxBps
+		if iface.Duplex == stats.DuplexHalf {
+			rxBits, txBits = rxBits+txBits, rxBits+txBits
+		}
+		rates[i].RxUtil = utilization(rxBits, rates[i].Speed)
+		rates[i].TxUtil = utilization(txBits, rates[i].Speed)
+		rates[i].RxTrend = app.ifaceTrend(iface.InterfaceName, "RxPackets")
+		rates[i].TxTrend = app.ifaceTrend(iface.InterfaceName, "TxPackets")
+	}
+	app.setPeaks(rates, app.peaks.take(), missed)
+	app.IfCache = ifaces
+	app.ifCacheTime = now
+	return rates
+}
+
+// formatUtil formats the utilization as a percentage.
+func formatUtil(v interface{}) string {
+	util := v.(float64)
+	if util == unknownUtil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", util)
+}
+
+// formatGauge formats the utilization followed by its gauge.
+func formatGauge(v interface{}) string {
+	return formatUtil(v) + " " + gauge(v.(float64), gaugeWidth)
+}
+
+// gauge draws the utilization as a bar of the width,
+// an unknown utilization is not drawn.
+func gauge(util float64, width int) string {
+	if util == unknownUtil {
+		return ""
+	}
+	eighths := int(math.Round(math.Min(util, 100) / 100 * float64(width*8)))
+	bar := strings.Repeat("█", eighths/8)
+	if eighths%8 != 0 {
+		bar += string(gaugeEighths[eighths%8])
+	}
+	return "▕" + bar + strings.Repeat(" ", width-(eighths+7)/8) + "▏"
+}
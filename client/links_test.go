@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+func TestUtilization(t *testing.T) {
+	tests := []struct {
+		bps   float64
+		speed uint64
+		want  float64
+	}{
+		{bps: 5e9, speed: 10e9, want: 50},
+		{bps: 2.5e9, speed: 10e9, want: 25},
+		{bps: 5e9, speed: 0, want: unknownUtil},
+	}
+	for _, test := range tests {
+		if got := utilization(test.bps, test.speed); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestGauge(t *testing.T) {
+	tests := []struct {
+		util float64
+		want string
+	}{
+		{util: 0, want: "▕    ▏"},
+		{util: 50, want: "▕██  ▏"},
+		{util: 62.5, want: "▕██▌ ▏"},
+		{util: 150, want: "▕████▏"},
+		{util: unknownUtil, want: ""},
+	}
+	for _, test := range tests {
+		if got := gauge(test.util, 4); got != test.want {
+			t.Errorf("Error occured got:%q; want:%q", got, test.want)
+		}
+	}
+}
+
+func TestApp_Rates(t *testing.T) {
+	app := &App{cfg: config.Default(), ifaceHistory: newHistory(time.Minute, time.Second), peaks: newPeakSampler()}
+	iface := func(packets uint64) []stats.Interface {
+		return []stats.Interface{{InterfaceCounters: stats.InterfaceCounters{
+			InterfaceName: "eth0",
+			Rx:            stats.InterfaceCounterCombined{Packets: packets, Bytes: packets * 100},
+		}}}
+	}
+	app.rates(iface(1000))
+	app.ifCacheTime = time.Now().Add(-2 * time.Second)
+	rates := app.rates(iface(3000))
+	if got := rates[0].RxPps; got < 900 || got > 1000 {
+		t.Errorf("Error occured got:%v; want:%v", got, 1000)
+	}
+
+	// the counters were cleared, they count from 0.
+	app.ifCacheTime = time.Now().Add(-2 * time.Second)
+	rates = app.rates(iface(200))
+	if got := rates[0].RxPps; got < 90 || got > 100 {
+		t.Errorf("Error occured got:%v; want:%v", got, 100)
+	}
+}
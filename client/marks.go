@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/gui"
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+// maxMarks is the number of kept marks, the oldest mark is dropped.
+const maxMarks = 9
+
+// markNotification is how long a new mark is shown in the notification.
+const markNotification = 3 * time.Second
+
+// mark is a baseline of the counters of the interfaces,
+// nodes and errors recorded at a moment.
+type mark struct {
+	name   string
+	ifaces map[string]stats.InterfaceCounters
+	nodes  map[string]stats.Node
+	errors map[string]uint64
+}
+
+// marks are the recorded marks, one of them is active.
+// In the delta mode the counters are shown relative
+// to the active mark instead of their absolute values.
+type marks struct {
+	sync.Mutex
+	list   []*mark
+	active int
+	delta  bool
+	// count numbers the marks.
+	count int
+}
+
+// add adds the mark, which becomes active, and turns the delta mode on.
+func (m *marks) add(mk *mark) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.count++
+	mk.name = fmt.Sprintf("mark %d %s", m.count, mk.name)
+	m.list = append(m.list, mk)
+	if len(m.list) > maxMarks {
+		m.list = m.list[len(m.list)-maxMarks:]
+	}
+	m.active = len(m.list) - 1
+	m.delta = true
+}
+
+// names returns the names of the marks and the index of the active one.
+func (m *marks) names() ([]string, int) {
+	m.Lock()
+	defer m.Unlock()
+
+	names := make([]string, len(m.list))
+	for i, mk := range m.list {
+		names[i] = mk.name
+	}
+	return names, m.active
+}
+
+// activate makes the mark at index active and turns the delta mode on.
+func (m *marks) activate(i int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if i >= 0 && i < len(m.list) {
+		m.active = i
+		m.delta = true
+	}
+}
+
+// toggle turns the delta mode on or off, it stays off without marks.
+func (m *marks) toggle() {
+	m.Lock()
+	defer m.Unlock()
+	m.delta = !m.delta && len(m.list) != 0
+}
+
+// baseline returns the active mark in the delta mode, nil otherwise.
+func (m *marks) baseline() *mark {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.delta || len(m.list) == 0 {
+		return nil
+	}
+	return m.list[m.active]
+}
+
+// ifaceDeltas returns the interfaces with the counters relative to the mark.
+func (mk *mark) ifaceDeltas(rates []ifaceRates) []ifaceRates {
+	deltas := make([]ifaceRates, len(rates))
+	for i, r := range rates {
+		deltas[i] = r
+		if base, ok := mk.ifaces[r.InterfaceName]; ok {
+			deltas[i].InterfaceCounters = counterDeltas(r.InterfaceCounters, base)
+		}
+	}
+	return deltas
+}
+
+// counterDeltas returns the increments of the counters since the base.
+func counterDeltas(c, base stats.InterfaceCounters) stats.InterfaceCounters {
+	combined := func(c, base stats.InterfaceCounterCombined) stats.InterfaceCounterCombined {
+		return stats.InterfaceCounterCombined{
+			Packets: counterDelta(c.Packets, base.Packets),
+			Bytes:   counterDelta(c.Bytes, base.Bytes),
+		}
+	}
+	c.Rx = combined(c.Rx, base.Rx)
+	c.Tx = combined(c.Tx, base.Tx)
+	c.RxErrors = counterDelta(c.RxErrors, base.RxErrors)
+	c.TxErrors = counterDelta(c.TxErrors, base.TxErrors)
+	c.RxUnicast = combined(c.RxUnicast, base.RxUnicast)
+	c.RxMulticast = combined(c.RxMulticast, base.RxMulticast)
+	c.RxBroadcast = combined(c.RxBroadcast, base.RxBroadcast)
+	c.TxUnicast = combined(c.TxUnicast, base.TxUnicast)
+	c.TxMulticast = combined(c.TxMulticast, base.TxMulticast)
+	c.TxBroadcast = combined(c.TxBroadcast, base.TxBroadcast)
+	c.Drops = counterDelta(c.Drops, base.Drops)
+	c.Punts = counterDelta(c.Punts, base.Punts)
+	c.IP4 = counterDelta(c.IP4, base.IP4)
+	c.IP6 = counterDelta(c.IP6, base.IP6)
+	c.RxNoBuf = counterDelta(c.RxNoBuf, base.RxNoBuf)
+	c.RxMiss = counterDelta(c.RxMiss, base.RxMiss)
+	return c
+}
+
+// nodeDeltas returns the nodes with the counters relative to the mark.
+// The clocks are an average, so they are kept.
+func (mk *mark) nodeDeltas(trends []nodeTrend) []nodeTrend {
+	nodes := make([]stats.Node, len(trends))
+	for i, t := range trends {
+		nodes[i] = t.Node
+	}
+	keys := nodeKeys(nodes)
+
+	deltas := make([]nodeTrend, len(trends))
+	for i, t := range trends {
+		deltas[i] = t
+		base, ok := mk.nodes[keys[i]]
+		if !ok {
+			continue
+		}
+		n := &deltas[i].Node
+		n.Calls = counterDelta(n.Calls, base.Calls)
+		n.Vectors = counterDelta(n.Vectors, base.Vectors)
+		n.Suspends = counterDelta(n.Suspends, base.Suspends)
+		n.VectorsPerCall = 0
+		if n.Calls != 0 {
+			n.VectorsPerCall = float64(n.Vectors) / float64(n.Calls)
+		}
+	}
+	return deltas
+}
+
+// errorDeltas returns the error counters relative to the mark.
+func (mk *mark) errorDeltas(trends []errorTrend) []errorTrend {
+	deltas := make([]errorTrend, len(trends))
+	for i, t := range trends {
+		deltas[i] = t
+		deltas[i].Value = counterDelta(t.Value, mk.errors[errorKey(t.Error)])
+	}
+	return deltas
+}
+
+// newMark records the counters of the interfaces, nodes and errors.
+func (app *App) newMark() (*mark, error) {
+	ifaces, err := app.vpp.GetInterfaceCounters()
+	if err != nil {
+		return nil, fmt.Errorf("interfaces: %v", err)
+	}
+	nodes, err := app.vpp.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("nodes: %v", err)
+	}
+	errors, err := app.vpp.GetErrors()
+	if err != nil {
+		return nil, fmt.Errorf("errors: %v", err)
+	}
+
+	mk := &mark{
+		name:   time.Now().Format("15:04:05"),
+		ifaces: make(map[string]stats.InterfaceCounters, len(ifaces)),
+		nodes:  make(map[string]stats.Node, len(nodes)),
+		errors: make(map[string]uint64, len(errors)),
+	}
+	for _, iface := range ifaces {
+		mk.ifaces[iface.InterfaceName] = iface.InterfaceCounters
+	}
+	for i, key := range nodeKeys(nodes) {
+		mk.nodes[key] = nodes[i]
+	}
+	for _, e := range errors {
+		mk.errors[errorKey(e)] = e.Value
+	}
+	return mk, nil
+}
+
+// addMark records a new mark in the background, the counters
+// are shown relative to it from the next refresh.
+func (app *App) addMark() {
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.vppLock.Lock()
+		mk, err := app.newMark()
+		app.vppLock.Unlock()
+		if err != nil {
+			log.Printf("error occured while recording a mark: %v\n", err)
+			return
+		}
+		app.marks.add(mk)
+		app.gui.Exec(func() {
+			app.gui.Notify("recorded "+mk.name, markNotification)
+			app.updateMode()
+		})
+	}()
+}
+
+// showMarks opens the list of the marks, the picked mark becomes active.
+func (app *App) showMarks() {
+	names, active := app.marks.names()
+	app.gui.ShowMarks(names, active, func(i int) {
+		app.marks.activate(i)
+		app.updateMode()
+	})
+}
+
+// toggleDelta switches between the counters relative
+// to the active mark and their absolute values.
+func (app *App) toggleDelta() {
+	app.marks.toggle()
+	app.updateMode()
+}
+
+// updateMode displays the active mark in the status in the delta mode.
+func (app *App) updateMode() {
+	mode := ""
+	if mk := app.marks.baseline(); mk != nil {
+		mode = fmt.Sprintf("Delta since %s (%v for absolute)", mk.name, gui.KeyDelta)
+	}
+	app.gui.SetMode(mode)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+func TestMark_Deltas(t *testing.T) {
+	mk := &mark{
+		ifaces: map[string]stats.InterfaceCounters{"eth0": {Rx: stats.InterfaceCounterCombined{Packets: 100, Bytes: 1000}, Drops: 5}},
+		nodes:  map[string]stats.Node{"ip4-lookup#0": {Name: "ip4-lookup", Calls: 10, Vectors: 20}},
+		errors: map[string]uint64{"ip4-input/ttl expired": 7},
+	}
+
+	var iface ifaceRates
+	iface.InterfaceName = "eth0"
+	iface.Rx.Packets, iface.Rx.Bytes, iface.Drops = 150, 1600, 3
+	got := mk.ifaceDeltas([]ifaceRates{iface})[0]
+	if got.Rx.Packets != 50 || got.Rx.Bytes != 600 || got.Drops != 3 {
+		t.Errorf("Error occured got:%v,%v,%v; want:%v,%v,%v", got.Rx.Packets, got.Rx.Bytes, got.Drops, 50, 600, 3)
+	}
+	if iface.Rx.Packets != 150 {
+		t.Errorf("Error occured got:%v; want:%v", iface.Rx.Packets, 150)
+	}
+
+	node := nodeTrend{Node: stats.Node{Name: "ip4-lookup", Calls: 14, Vectors: 40}}
+	gotNode := mk.nodeDeltas([]nodeTrend{node})[0]
+	if gotNode.Calls != 4 || gotNode.Vectors != 20 || gotNode.VectorsPerCall != 5 {
+		t.Errorf("Error occured got:%v,%v,%v; want:%v,%v,%v", gotNode.Calls, gotNode.Vectors, gotNode.VectorsPerCall, 4, 20, 5)
+	}
+
+	errs := []errorTrend{
+		{Error: stats.Error{Node: "ip4-input", Name: "ttl expired", Value: 10}},
+		{Error: stats.Error{Node: "ip4-input", Name: "bad checksum", Value: 2}},
+	}
+	gotErrs := mk.errorDeltas(errs)
+	if gotErrs[0].Value != 3 || gotErrs[1].Value != 2 {
+		t.Errorf("Error occured got:%v,%v; want:%v,%v", gotErrs[0].Value, gotErrs[1].Value, 3, 2)
+	}
+}
+
+func TestMarks(t *testing.T) {
+	m := new(marks)
+	m.toggle()
+	if m.baseline() != nil {
+		t.Errorf("Error occured got:%v; want:%v", m.baseline(), nil)
+	}
+	for i := 0; i < maxMarks+1; i++ {
+		m.add(&mark{name: "00:00:00"})
+	}
+	names, active := m.names()
+	if len(names) != maxMarks || active != maxMarks-1 || names[0] != "mark 2 00:00:00" {
+		t.Errorf("Error occured got:%v,%v,%v; want:%v,%v,%v", len(names), active, names[0], maxMarks, maxMarks-1, "mark 2 00:00:00")
+	}
+	m.activate(0)
+	if m.baseline() == nil || m.baseline().name != names[0] {
+		t.Errorf("Error occured got:%v; want:%v", m.baseline(), names[0])
+	}
+	m.toggle()
+	if m.baseline() != nil {
+		t.Errorf("Error occured got:%v; want:%v", m.baseline(), nil)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+// overviewTop is the number of the busiest
+// interfaces, nodes and errors in the overview.
+const overviewTop = 5
+
+// frameSize is the maximum number of vectors processed by a node
+// in one call, the load of a worker is its vectors per node against it.
+const frameSize = 256
+
+// memoryTotal and memoryUsed match the total and
+// used heap memory in the memory stats of a thread.
+var (
+	memoryTotal = regexp.MustCompile(`total: ([0-9.]+[kKMGT]?)`)
+	memoryUsed  = regexp.MustCompile(`used: ([0-9.]+[kKMGT]?)`)
+)
+
+// overview is the summary of the box.
+type overview struct {
+	version string
+	// uptime is the time since VPP started, 0 if not known.
+	uptime time.Duration
+	// totals of the rates of all interfaces per second.
+	rxPps, txPps, rxBps, txBps float64
+	drops, punts               float64
+	ifaces                     []ifaceTraffic
+	nodes                      []nodeLoad
+	errors                     []errorGrowth
+	workers                    []workerVectors
+	heap                       []heapUsage
+}
+
+// ifaceTraffic are the rates of an interface per second.
+type ifaceTraffic struct {
+	name         string
+	rxBps, txBps float64
+	pps          float64
+}
+
+// nodeLoad is the load of a node summed up over the threads.
+type nodeLoad struct {
+	name string
+	// clocks are the highest clocks per vector of the threads.
+	clocks         float64
+	vectors, calls float64
+}
+
+// errorGrowth is the increase of an error counter per second.
+type errorGrowth struct {
+	name  string
+	rate  float64
+	count uint64
+}
+
+// workerVectors is the average number of
+// vectors processed by a node of a thread.
+type workerVectors struct {
+	name           string
+	vectorsPerNode float64
+}
+
+// heapUsage is the heap memory of a thread in bytes.
+type heapUsage struct {
+	name        string
+	used, total float64
+}
+
+// overviewPoll keeps the counters of the previous poll
+// of the overview to compute the rates.
+type overviewPoll struct {
+	time   time.Time
+	ifaces map[string]stats.InterfaceCounters
+	nodes  map[string]stats.Node
+	errors map[string]uint64
+}
+
+// pollOverview polls the stats summarized in the overview.
+func (app *App) pollOverview() overview {
+	o := overview{version: app.version}
+	now := time.Now()
+	prev := app.overviewPoll
+	next := &overviewPoll{
+		time:   now,
+		ifaces: make(map[string]stats.InterfaceCounters),
+		nodes:  make(map[string]stats.Node),
+		errors: make(map[string]uint64),
+	}
+	app.overviewPoll = next
+	// the overview is polled only while it is displayed, the rates
+	// are not averaged over the time it was not.
+	secs := 0.0
+	if prev != nil && now.Sub(prev.time) <= 2*app.cfg.Interval {
+		secs = now.Sub(prev.time).Seconds()
+	} else {
+		prev = new(overviewPoll)
+	}
+	rate := func(curr, last uint64) float64 {
+		if secs <= 0 {
+			return 0
+		}
+		return float64(counterDelta(curr, last)) / secs
+	}
+
+	ifaces, err := app.vpp.GetInterfaceCounters()
+	if err != nil {
+		log.Printf("error occured while polling interface counters: %v\n", err)
+	}
+	for _, iface := range ifaces {
+		next.ifaces[iface.InterfaceName] = iface.InterfaceCounters
+		last, ok := prev.ifaces[iface.InterfaceName]
+		if !ok {
+			continue
+		}
+		t := ifaceTraffic{
+			name:  iface.InterfaceName,
+			rxBps: 8 * rate(iface.Rx.Bytes, last.Rx.Bytes),
+			txBps: 8 * rate(iface.Tx.Bytes, last.Tx.Bytes),
+			pps:   rate(iface.Rx.Packets, last.Rx.Packets) + rate(iface.Tx.Packets, last.Tx.Packets),
+		}
+		o.rxPps += rate(iface.Rx.Packets, last.Rx.Packets)
+		o.txPps += rate(iface.Tx.Packets, last.Tx.Packets)
+		o.rxBps += t.rxBps
+		o.txBps += t.txBps
+		o.drops += rate(iface.Drops, last.Drops)
+		o.punts += rate(iface.Punts, last.Punts)
+		o.ifaces = append(o.ifaces, t)
+	}
+	sort.SliceStable(o.ifaces, func(i, j int) bool {
+		return o.ifaces[i].rxBps+o.ifaces[i].txBps > o.ifaces[j].rxBps+o.ifaces[j].txBps
+	})
+	o.ifaces = o.ifaces[:top(len(o.ifaces))]
+
+	nodes, threads, err := app.vpp.GetRuntime()
+	if err != nil {
+		log.Printf("error occured while polling nodes stats: %v\n", err)
+	}
+	byName := make(map[string]*nodeLoad)
+	for i, key := range nodeKeys(nodes) {
+		node := nodes[i]
+		next.nodes[key] = node
+		l, ok := byName[node.Name]
+		if !ok {
+			l = &nodeLoad{name: node.Name}
+			byName[node.Name] = l
+		}
+		l.clocks = math.Max(l.clocks, node.Clocks)
+		if last, ok := prev.nodes[key]; ok {
+			l.vectors += rate(node.Vectors, last.Vectors)
+			l.calls += rate(node.Calls, last.Calls)
+		}
+	}
+	for _, l := range byName {
+		o.nodes = append(o.nodes, *l)
+	}
+	sort.Slice(o.nodes, func(i, j int) bool {
+		if o.nodes[i].clocks != o.nodes[j].clocks {
+			return o.nodes[i].clocks > o.nodes[j].clocks
+		}
+		return o.nodes[i].name < o.nodes[j].name
+	})
+	o.nodes = o.nodes[:top(len(o.nodes))]
+
+	errors, err := app.vpp.GetErrors()
+	if err != nil {
+		log.Printf("error occured while polling errors stats: %v\n", err)
+	}
+	for _, e := range errors {
+		key := errorKey(e)
+		next.errors[key] = e.Value
+		if last, ok := prev.errors[key]; ok {
+			if r := rate(e.Value, last); r > 0 {
+				o.errors = append(o.errors, errorGrowth{name: key, rate: r, count: e.Value})
+			}
+		}
+	}
+	sort.SliceStable(o.errors, func(i, j int) bool { return o.errors[i].rate > o.errors[j].rate })
+	o.errors = o.errors[:top(len(o.errors))]
+
+	for _, thread := range threads {
+		o.workers = append(o.workers, workerVectors{name: thread.Name, vectorsPerNode: thread.AvgVectorsPerNode})
+	}
+
+	o.uptime, err = app.vpp.Uptime()
+	if err != nil {
+		log.Printf("error occured while polling uptime: %v\n", err)
+	}
+
+	memstats, err := app.vpp.Memory()
+	if err != nil {
+		log.Printf("error occured while polling memory stats: %v\n", err)
+	}
+	o.heap = heapOf(memstats, memstatsPerThread)
+	return o
+}
+
+// top returns the number of the shown entries of the n busiest ones.
+func top(n int) int {
+	if n > overviewTop {
+		return overviewTop
+	}
+	return n
+}
+
+// heapOf returns the heap usage of the threads. The memory stats
+// consist of rowsPerEntry lines per thread, starting with its name.
+func heapOf(memstats []string, rowsPerEntry int) []heapUsage {
+	var heap []heapUsage
+	for i := 0; i+rowsPerEntry <= len(memstats); i += rowsPerEntry {
+		for _, line := range memstats[i : i+rowsPerEntry] {
+			total, used := memoryTotal.FindStringSubmatch(line), memoryUsed.FindStringSubmatch(line)
+			if total == nil || used == nil {
+				continue
+			}
+			h := heapUsage{name: memstats[i]}
+			h.total, _ = xtui.ParseNumber(total[1])
+			h.used, _ = xtui.ParseNumber(used[1])
+			heap = append(heap, h)
+			break
+		}
+	}
+	return heap
+}
+
+// formatUptime formats the uptime as days, hours, minutes and seconds.
+func formatUptime(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	s := int64(d / time.Second)
+	days := s / 86400
+	clock := fmt.Sprintf("%02d:%02d:%02d", s%86400/3600, s%3600/60, s%60)
+	if days == 0 {
+		return clock
+	}
+	return fmt.Sprintf("%dd %s", days, clock)
+}
+
+// percent returns the part of the whole in percent, unknownUtil if the
+// whole is 0.
+func percent(part, whole float64) float64 {
+	if whole <= 0 {
+		return unknownUtil
+	}
+	return part / whole * 100
+}
+
+// overviewRows formats the overview to the rows of the table,
+// the sections start with a row naming their columns.
+func overviewRows(o overview, u units) xtui.TableRows {
+	rows := xtui.TableRows{
+		{"VPP", o.version, xtui.EmptyCell, xtui.EmptyCell},
+		{"Uptime", formatUptime(o.uptime), xtui.EmptyCell, xtui.EmptyCell},
+		{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		{"Traffic", "Packets/s", "Bits/s", xtui.EmptyCell},
+		{"  Rx", u.format(o.rxPps, unitPps), u.format(o.rxBps, unitBps), xtui.EmptyCell},
+		{"  Tx", u.format(o.txPps, unitPps), u.format(o.txBps, unitBps), xtui.EmptyCell},
+		{"  Drops", u.format(o.drops, unitPps), xtui.EmptyCell, xtui.EmptyCell},
+		{"  Punts", u.format(o.punts, unitPps), xtui.EmptyCell, xtui.EmptyCell},
+		{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		{"Top interfaces", "Rx bits/s", "Tx bits/s", "Packets/s"},
+	}
+	for _, t := range o.ifaces {
+		rows = append(rows, []string{"  " + t.name, u.format(t.rxBps, unitBps), u.format(t.txBps, unitBps), u.format(t.pps, unitPps)})
+	}
+	rows = append(rows,
+		[]string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		[]string{"Top nodes", "Clocks", "Vectors/s", "Calls/s"},
+	)
+	for _, n := range o.nodes {
+		rows = append(rows, []string{"  " + n.name, u.format(n.clocks, unitClocks), u.format(n.vectors, unitPps), u.format(n.calls, "")})
+	}
+	rows = append(rows,
+		[]string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		[]string{"Top growing errors", "Errors/s", "Count", xtui.EmptyCell},
+	)
+	for _, e := range o.errors {
+		rows = append(rows, []string{"  " + e.name, u.format(e.rate, ""), u.formatUint(e.count, unitPackets), xtui.EmptyCell})
+	}
+	rows = append(rows,
+		[]string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		[]string{"Workers", "Vectors/node", xtui.EmptyCell, "Load"},
+	)
+	for _, w := range o.workers {
+		load := percent(w.vectorsPerNode, frameSize)
+		rows = append(rows, []string{"  " + w.name, fmt.Sprintf("%.2f", w.vectorsPerNode), xtui.EmptyCell, formatUtil(load) + " " + gauge(load, gaugeWidth)})
+	}
+	rows = append(rows,
+		[]string{xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell, xtui.EmptyCell},
+		[]string{"Heap", "Used", "Total", xtui.EmptyCell},
+	)
+	for _, h := range o.heap {
+		used := percent(h.used, h.total)
+		rows = append(rows, []string{"  " + h.name, u.format(h.used, unitBytes), u.format(h.total, unitBytes), formatUtil(used) + " " + gauge(used, gaugeWidth)})
+	}
+	return rows
+}
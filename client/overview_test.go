@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		uptime time.Duration
+		want   string
+	}{
+		{uptime: 0, want: "-"},
+		{uptime: 65 * time.Second, want: "00:01:05"},
+		{uptime: 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second, want: "3d 04:05:06"},
+	}
+	for _, test := range tests {
+		if got := formatUptime(test.uptime); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestHeapOf(t *testing.T) {
+	memstats := []string{
+		"Thread 0 vpp_main",
+		"virtual memory start 0x7f0a2b2e3000, size 1048640k, 262160 pages, page size 4k",
+		"numa 0: 262160 pages, 1.00g bytes",
+		"total: 1023.99M, used: 256.00M, free: 767.99M, trimmable: 767.00M",
+		"free chunks 300 free fastbin blks 0",
+		"max total allocated 1023.99M",
+		"",
+	}
+	heap := heapOf(memstats, memstatsPerThread)
+	if len(heap) != 1 || heap[0].name != "Thread 0 vpp_main" {
+		t.Fatalf("Error occured got:%v; want:%v", heap, "Thread 0 vpp_main")
+	}
+	if used := percent(heap[0].used, heap[0].total); used < 25 || used > 25.1 {
+		t.Errorf("Error occured got:%v; want:%v", used, 25)
+	}
+}
+
+func TestOverviewRows(t *testing.T) {
+	o := overview{
+		version: "20.01",
+		ifaces:  make([]ifaceTraffic, overviewTop),
+		workers: []workerVectors{{name: "vpp_wk_0", vectorsPerNode: 128}},
+	}
+	rows := overviewRows(o, units{})
+	// the fixed rows, 5 sections with a blank row and a header and the entries.
+	if want := 8 + 5*2 + overviewTop + 1; len(rows) != want {
+		t.Errorf("Error occured got:%v; want:%v", len(rows), want)
+	}
+	for _, row := range rows {
+		if len(row) != 4 {
+			t.Errorf("Error occured got:%v; want:%v", len(row), 4)
+		}
+	}
+	if got := rows[len(rows)-3][1]; got != "128.00" {
+		t.Errorf("Error occured got:%v; want:%v", got, "128.00")
+	}
+	// the load of the worker is half of the frame.
+	if got, want := rows[len(rows)-3][3], "50.0% "+gauge(50, gaugeWidth); got != want {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
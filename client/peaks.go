@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+// burst flags of the interface peaks.
+const (
+	burstRxOverLink = "rx>link"
+	burstTxOverLink = "tx>link"
+	burstMiss       = "miss"
+)
+
+// peakWindow holds the highest rates per second of an interface
+// sampled within a refresh interval.
+type peakWindow struct {
+	rxPps, txPps float64
+	rxBps, txBps float64
+	// rxSample is the number of the sample with the highest rx rate.
+	rxSample int
+	// missAtPeak is set if the rx-miss counter increased in the sample
+	// with the highest rx rate or in the following one, as the missed
+	// packets of a burst may be counted a sample later.
+	missAtPeak bool
+}
+
+// peakSampler finds the peak rates of the interfaces from
+// the counters sampled many times per refresh interval.
+type peakSampler struct {
+	sync.Mutex
+	prev     map[string]stats.Interface
+	prevTime time.Time
+	samples  int
+	windows  map[string]*peakWindow
+}
+
+func newPeakSampler() *peakSampler {
+	return &peakSampler{windows: make(map[string]*peakWindow)}
+}
+
+// add computes the rates from the previous sample taken at now,
+// and keeps the highest ones of the current window.
+func (p *peakSampler) add(ifaces []stats.Interface, now time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	prev, dt := p.prev, now.Sub(p.prevTime).Seconds()
+	p.prev = make(map[string]stats.Interface, len(ifaces))
+	p.prevTime = now
+	for _, iface := range ifaces {
+		p.prev[iface.InterfaceName] = iface
+	}
+	if prev == nil || dt <= 0 {
+		return
+	}
+
+	p.samples++
+	for _, iface := range ifaces {
+		last, ok := prev[iface.InterfaceName]
+		if !ok {
+			continue
+		}
+		w, ok := p.windows[iface.InterfaceName]
+		if !ok {
+			w = &peakWindow{rxSample: -1}
+			p.windows[iface.InterfaceName] = w
+		}
+		missed := counterDelta(iface.RxMiss, last.RxMiss) > 0
+		if rxPps := float64(counterDelta(iface.Rx.Packets, last.Rx.Packets)) / dt; rxPps > w.rxPps {
+			w.rxPps, w.rxSample, w.missAtPeak = rxPps, p.samples, missed
+		} else if missed && w.rxSample == p.samples-1 {
+			w.missAtPeak = true
+		}
+		w.txPps = math.Max(w.txPps, float64(counterDelta(iface.Tx.Packets, last.Tx.Packets))/dt)
+		w.rxBps = math.Max(w.rxBps, float64(8*counterDelta(iface.Rx.Bytes, last.Rx.Bytes))/dt)
+		w.txBps = math.Max(w.txBps, float64(8*counterDelta(iface.Tx.Bytes, last.Tx.Bytes))/dt)
+	}
+}
+
+// take returns the peaks of the current window and starts a new one.
+func (p *peakSampler) take() map[string]*peakWindow {
+	p.Lock()
+	defer p.Unlock()
+
+	windows := p.windows
+	p.windows = make(map[string]*peakWindow)
+	p.samples = 0
+	return windows
+}
+
+// reset forgets the previous sample and the current window,
+// e.g. after the counters were cleared or the sampling paused.
+func (p *peakSampler) reset() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.prev = nil
+	p.samples = 0
+	p.windows = make(map[string]*peakWindow)
+}
+
+// counterDelta returns the increment of the counter,
+// a counter which decreased, e.g. after it was cleared, counts from 0.
+func counterDelta(curr, prev uint64) uint64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+// samplePeaks samples the interface counters at the interval while the
+// interfaces are displayed or watched by the alerts, until ctx is done.
+func (app *App) samplePeaks(ctx context.Context, interval time.Duration, currTab func() int) {
+	defer app.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if currTab() != Interfaces && !app.alerts.watches(Interfaces) {
+				app.peaks.reset()
+				continue
+			}
+			app.vppLock.Lock()
+			ifaces, err := app.vpp.GetInterfaceCounters()
+			now := time.Now()
+			app.vppLock.Unlock()
+			if err != nil {
+				log.Printf("error occured while sampling interface counters: %v\n", err)
+				app.peaks.reset()
+				continue
+			}
+			app.peaks.add(ifaces, now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// rateRange is the minimum, maximum and
+// average of the rates seen in the session.
+type rateRange struct {
+	min, max, sum float64
+	n             int
+}
+
+// add adds the rate to the range.
+func (r *rateRange) add(v float64) {
+	if r.n == 0 || v < r.min {
+		r.min = v
+	}
+	if r.n == 0 || v > r.max {
+		r.max = v
+	}
+	r.sum += v
+	r.n++
+}
+
+// avg returns the average of the rates, 0 if there are none.
+func (r *rateRange) avg() float64 {
+	if r.n == 0 {
+		return 0
+	}
+	return r.sum / float64(r.n)
+}
+
+// ifaceSession are the ranges of the bit rates of an interface.
+type ifaceSession struct {
+	rx, tx rateRange
+}
+
+// setPeaks fills in the peaks, the burst flags and the session ranges
+// of the rates computed from the previous poll. The interfaces without
+// sampled peaks get the rates of the interval as their peaks.
+func (app *App) setPeaks(rates []ifaceRates, peaks map[string]*peakWindow, missed map[string]bool) {
+	sessions := make(map[string]*ifaceSession, len(rates))
+	for i := range rates {
+		r := &rates[i]
+		name := r.InterfaceName
+		rxBps, txBps := r.RxBps, r.TxBps
+
+		r.RxPeakPps, r.TxPeakPps = r.RxPps, r.TxPps
+		r.RxPeak, r.TxPeak = rxBps, txBps
+		miss := missed[name]
+		if p, ok := peaks[name]; ok {
+			r.RxPeakPps, r.TxPeakPps = math.Max(r.RxPeakPps, p.rxPps), math.Max(r.TxPeakPps, p.txPps)
+			r.RxPeak, r.TxPeak = math.Max(r.RxPeak, p.rxBps), math.Max(r.TxPeak, p.txBps)
+			miss = p.missAtPeak
+		}
+		r.Burst = burstFlags(*r, miss)
+
+		s, ok := app.ifaceSessions[name]
+		if !ok {
+			s = new(ifaceSession)
+		}
+		sessions[name] = s
+		if _, polled := missed[name]; polled {
+			s.rx.add(rxBps)
+			s.tx.add(txBps)
+		}
+		r.RxMin, r.RxAvg, r.RxMax = s.rx.min, s.rx.avg(), s.rx.max
+		r.TxMin, r.TxAvg, r.TxMax = s.tx.min, s.tx.avg(), s.tx.max
+	}
+	app.ifaceSessions = sessions
+}
+
+// burstFlags returns the flags of the peaks exceeding the link speed,
+// and of the rx peak with missed packets. The directions of a half
+// duplex link share it, so their peaks are added up.
+func burstFlags(r ifaceRates, miss bool) string {
+	var flags []string
+	if r.Speed != 0 {
+		rx, tx := r.RxPeak, r.TxPeak
+		if r.Duplex == stats.DuplexHalf {
+			rx, tx = rx+tx, rx+tx
+		}
+		if rx > float64(r.Speed) {
+			flags = append(flags, burstRxOverLink)
+		}
+		if tx > float64(r.Speed) {
+			flags = append(flags, burstTxOverLink)
+		}
+	}
+	if miss {
+		flags = append(flags, burstMiss)
+	}
+	return strings.Join(flags, ",")
+}
+
+// formatRate formats the raw rate as an integer.
+func formatRate(v interface{}) string {
+	return fmt.Sprint(uint64(math.Round(v.(float64))))
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PantheonTechnologies/vpptop/stats"
+)
+
+func counters(name string, rxPackets, rxBytes, rxMiss uint64) stats.Interface {
+	var iface stats.Interface
+	iface.InterfaceName = name
+	iface.Rx.Packets = rxPackets
+	iface.Rx.Bytes = rxBytes
+	iface.RxMiss = rxMiss
+	return iface
+}
+
+func TestPeakSampler(t *testing.T) {
+	p := newPeakSampler()
+	now := time.Now()
+	step := 100 * time.Millisecond
+	p.add([]stats.Interface{counters("eth0", 0, 0, 0)}, now)
+	p.add([]stats.Interface{counters("eth0", 100, 1000, 0)}, now.Add(step))
+	p.add([]stats.Interface{counters("eth0", 600, 6000, 0)}, now.Add(2*step))
+	p.add([]stats.Interface{counters("eth0", 700, 7000, 5)}, now.Add(3*step))
+
+	w := p.take()["eth0"]
+	if w == nil {
+		t.Fatalf("Error occured got:%v; want:%v", w, "a window")
+	}
+	if w.rxPps != 5000 || w.rxBps != 400000 {
+		t.Errorf("Error occured got:%v,%v; want:%v,%v", w.rxPps, w.rxBps, 5000, 400000)
+	}
+	if !w.missAtPeak {
+		t.Errorf("Error occured got:%v; want:%v", w.missAtPeak, true)
+	}
+
+	// the counters were cleared.
+	p.add([]stats.Interface{counters("eth0", 10, 100, 5)}, now.Add(4*step))
+	w = p.take()["eth0"]
+	if w.rxPps != 100 || w.missAtPeak {
+		t.Errorf("Error occured got:%v,%v; want:%v,%v", w.rxPps, w.missAtPeak, 100, false)
+	}
+}
+
+func TestBurstFlags(t *testing.T) {
+	tests := []struct {
+		rates ifaceRates
+		miss  bool
+		want  string
+	}{
+		{rates: ifaceRates{Speed: 1e9, RxPeak: 5e8, TxPeak: 5e8}, want: ""},
+		{rates: ifaceRates{Speed: 1e9, RxPeak: 2e9, TxPeak: 5e8}, miss: true, want: "rx>link,miss"},
+		{rates: ifaceRates{Speed: 0, RxPeak: 2e9}, want: ""},
+		{rates: ifaceRates{Interface: stats.Interface{Duplex: stats.DuplexHalf}, Speed: 1e9, RxPeak: 6e8, TxPeak: 6e8}, want: "rx>link,tx>link"},
+	}
+	for _, test := range tests {
+		if got := burstFlags(test.rates, test.miss); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestRateRange(t *testing.T) {
+	var r rateRange
+	for _, v := range []float64{4, 2, 6} {
+		r.add(v)
+	}
+	if r.min != 2 || r.max != 6 || r.avg() != 4 {
+		t.Errorf("Error occured got:%v,%v,%v; want:%v,%v,%v", r.min, r.max, r.avg(), 2, 6, 4)
+	}
+}
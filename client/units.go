@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/PantheonTechnologies/vpptop/config"
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+)
+
+// units describes how the values with a unit are formatted.
+// The values are sorted and compared by their raw value in any case.
+type units struct {
+	// human formats the values with prefixes, e.g. 1.5 Mpps,
+	// instead of raw integers.
+	human bool
+	// binary uses the binary prefixes for the bytes, e.g. KiB.
+	binary bool
+	// byteRates displays the bit rates in bytes per second.
+	byteRates bool
+}
+
+// format formats the value with the unit.
+func (u units) format(v float64, unit string) string {
+	if unit == unitBps && u.byteRates {
+		v, unit = v/8, unitByteRate
+	}
+	if !u.human {
+		return fmt.Sprint(uint64(v))
+	}
+	switch unit {
+	case unitBytes:
+		return u.bytes(v, "B")
+	case unitByteRate:
+		return u.bytes(v, "B/s")
+	case unitPps:
+		return xtui.FormatNumber(v, "pps")
+	case unitBps:
+		return xtui.FormatNumber(v, "bps")
+	}
+	return xtui.FormatNumber(v, "")
+}
+
+// formatUint formats the integer value with the unit.
+func (u units) formatUint(v uint64, unit string) string {
+	return u.format(float64(v), unit)
+}
+
+// bytes formats the number of bytes with the unit.
+func (u units) bytes(v float64, unit string) string {
+	if u.binary {
+		return xtui.FormatBinary(v, unit)
+	}
+	return xtui.FormatNumber(v, unit)
+}
+
+// configUnits returns the formatting of the values in the configuration.
+func configUnits(cfg *config.Config) units {
+	return units{
+		human:     cfg.HumanUnits,
+		binary:    cfg.BinaryPrefixes,
+		byteRates: cfg.ByteRates,
+	}
+}
+
+// setUnits changes the formatting of the values,
+// including the sums of the grouped entries.
+func (app *App) setUnits(u units) {
+	app.tabLock.Lock()
+	app.units = u
+	app.tabLock.Unlock()
+
+	for tab := range tabNames {
+		if app.isGrouped(tab) {
+			app.setGroup(tab, true)
+		}
+	}
+}
+
+// getUnits returns the formatting of the values.
+func (app *App) getUnits() units {
+	app.tabLock.Lock()
+	defer app.tabLock.Unlock()
+	return app.units
+}
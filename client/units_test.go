@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import "testing"
+
+func TestUnits_Format(t *testing.T) {
+	tests := []struct {
+		units units
+		value float64
+		unit  string
+		want  string
+	}{
+		{units{}, 1500000, unitPackets, "1500000"},
+		{units{human: true}, 1500000, unitPackets, "1.5M"},
+		{units{human: true}, 1500000, unitPps, "1.5 Mpps"},
+		{units{human: true}, 1536, unitBytes, "1.5 kB"},
+		{units{human: true, binary: true}, 1536, unitBytes, "1.5 KiB"},
+		{units{human: true}, 8000, unitBps, "8 kbps"},
+		{units{human: true, byteRates: true}, 8000, unitBps, "1 kB/s"},
+		{units{byteRates: true}, 8000, unitBps, "1000"},
+	}
+	for _, test := range tests {
+		if got := test.units.format(test.value, test.unit); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+
+	// rows keeps the raw values of the columns.
+	rates := []ifaceRates{{RxBps: 8000}}
+	if got := compactIfaceColumns.rows(rates)[0][compactIfaceColumns.index("RxBps")]; got != "8000" {
+		t.Errorf("Error occured got:%v; want:%v", got, "8000")
+	}
+}
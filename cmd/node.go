@@ -19,12 +19,14 @@ package cmd
 import (
 	"errors"
 	"log"
+	"net"
 	"path/filepath"
 	"time"
 
 	"git.fd.io/govpp.git/adapter/socketclient"
 	"git.fd.io/govpp.git/adapter/statsclient"
 	"git.fd.io/govpp.git/proxy"
+	"github.com/PantheonTechnologies/vpptop/config"
 	"github.com/spf13/cobra"
 )
 
@@ -35,6 +37,14 @@ var nodeCmd = &cobra.Command{
 		if len(args) < 1 {
 			return errors.New("no node specified")
 		}
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if addr, found := cfg.NodeAddr(args[0]); found {
+			return startClient(cmd, addr, args[0], "remote.log")
+		}
+
 		kubeconfig, err := cmd.Flags().GetString("kubeconfig")
 		if err != nil {
 			return err
@@ -42,7 +52,7 @@ var nodeCmd = &cobra.Command{
 
 		ipaddr, found := resolveNode(kubeconfig, args[0])
 		if found {
-			return startClient("", ipaddr+":"+"7878", "remote.log")
+			return startClient(cmd, net.JoinHostPort(ipaddr, config.DefaultNodePort), args[0], "remote.log")
 		}
 		log.Println("failed to resolve addr:", args[0])
 
@@ -62,14 +72,8 @@ var nodeCmd = &cobra.Command{
 		if err != nil {
 			log.Println("no server found")
 			log.Println("starting local server at:", raddr)
-			binapiSocket, err := cmd.Flags().GetString("binapi-socket")
-			if err != nil {
-				return err
-			}
-			statsSocket, err := cmd.Flags().GetString("stats-socket")
-			if err != nil {
-				return err
-			}
+			binapiSocket := cfg.BinapiSocket
+			statsSocket := cfg.StatsSocket
 			go func() {
 				p, err := proxy.NewServer()
 				if err != nil {
@@ -92,7 +96,7 @@ var nodeCmd = &cobra.Command{
 				p.ListenAndServe(raddr)
 			}()
 		}
-		return startClient("", raddr, "remote.log")
+		return startClient(cmd, raddr, args[0], "remote.log")
 	},
 }
 
@@ -19,6 +19,7 @@ package cmd
 import (
 	"log"
 
+	"github.com/PantheonTechnologies/vpptop/config"
 	"github.com/PantheonTechnologies/vpptop/stats"
 	"github.com/spf13/cobra"
 )
@@ -36,20 +37,21 @@ Memory usage:   free, used...
 Thread info:    name, type, PID...`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		socket, err := cmd.Flags().GetString("socket")
-		if err != nil {
-			return err
-		}
 		logFile, err := cmd.Flags().GetString("log")
 		if err != nil {
 			return err
 		}
-		return startClient(socket, "", logFile)
+		return startClient(cmd, "", "", logFile)
 	},
 }
 
 func init() {
+	rootCmd.PersistentFlags().String("config", "", "Config file (default $XDG_CONFIG_HOME/vpptop/config.yaml)")
+	rootCmd.PersistentFlags().Duration("interval", config.DefaultInterval, "Interval at which the stats are polled")
+	rootCmd.PersistentFlags().String("theme", "", "Color theme (dark|light|high-contrast|colorblind|monochrome) or path to a theme file")
+	rootCmd.PersistentFlags().String("tab", "", "Tab shown on start")
 	rootCmd.Flags().StringP("socket", "s", stats.DefaultSocket, "vpp stats segment socket")
+	rootCmd.Flags().String("binapi-socket", stats.DefaultBinapiSocket, "Path to VPP binapi socket")
 	rootCmd.Flags().StringP("log", "l", "vpptop.log", "Log file")
 }
 
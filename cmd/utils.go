@@ -21,9 +21,13 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/PantheonTechnologies/vpptop/client"
+	"github.com/PantheonTechnologies/vpptop/config"
 	"github.com/PantheonTechnologies/vpptop/gui"
+	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -32,26 +36,114 @@ import (
 
 // startClient is a blocking call that starts
 // the terminal frontend for displaying VPP metrics.
-func startClient(socket, raddr, logFile string) error {
-	var lightTheme bool
-	if _, lightTheme = os.LookupEnv("VPPTOP_THEME_LIGHT"); lightTheme {
-		gui.SetLightTheme()
+// The gui state is saved under the session key, or under
+// the stats socket path if the key is empty.
+// On SIGHUP the config is reloaded.
+func startClient(cmd *cobra.Command, raddr, session, logFile string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	theme, err := gui.LoadTheme(cfg.Theme, config.ThemesDir())
+	if err != nil {
+		return err
 	}
+	gui.SetTheme(theme)
 	logs, err := os.Create(logFile)
 	if err != nil {
 		return fmt.Errorf("error occured while creating file: %v", err)
 	}
 	defer logs.Close()
 
-	app := client.NewApp(lightTheme)
-	if err := app.Init(socket, raddr); err != nil {
+	log.SetOutput(logs)
+	if session == "" {
+		session = cfg.StatsSocket
+	}
+	app := client.NewApp(cfg)
+	if err := app.Init(raddr, session); err != nil {
 		return fmt.Errorf("error occurred during client init: %v", err)
 	}
-	log.SetOutput(logs)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	defer func() {
+		signal.Stop(hup)
+		close(done)
+	}()
+	go func() {
+		for {
+			select {
+			case <-hup:
+			case <-done:
+				return
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				log.Printf("error occurred while reloading config: %v\n", err)
+				continue
+			}
+			theme, err := gui.LoadTheme(cfg.Theme, config.ThemesDir())
+			if err != nil {
+				log.Printf("error occurred while reloading theme: %v\n", err)
+				continue
+			}
+			log.Println("config reloaded")
+			app.Reload(cfg, theme)
+		}
+	}()
+
 	app.Run()
 	return nil
 }
 
+// loadConfig loads the config file and overrides
+// its values with the flags set on the command line.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := []struct {
+		name string
+		val  *string
+	}{
+		{name: "socket", val: &cfg.StatsSocket},
+		{name: "stats-socket", val: &cfg.StatsSocket},
+		{name: "binapi-socket", val: &cfg.BinapiSocket},
+		{name: "theme", val: &cfg.Theme},
+		{name: "tab", val: &cfg.DefaultTab},
+	}
+	for _, f := range flags {
+		if flag := cmd.Flags().Lookup(f.name); flag != nil && (flag.Changed || *f.val == "") {
+			*f.val = flag.Value.String()
+		}
+	}
+	if cmd.Flags().Changed("interval") {
+		if cfg.Interval, err = cmd.Flags().GetDuration("interval"); err != nil {
+			return nil, err
+		}
+	}
+	if !cmd.Flags().Changed("theme") {
+		if _, ok := os.LookupEnv("VPPTOP_THEME_LIGHT"); ok {
+			cfg.Theme = config.ThemeLight
+		}
+		// https://no-color.org
+		if os.Getenv("NO_COLOR") != "" {
+			cfg.Theme = config.ThemeMonochrome
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %v", err)
+	}
+	return cfg, nil
+}
+
 // resolveNode resolves an ip address from a given nodeName/ip-addr.
 func resolveNode(kubeconfig string, name string) (string, bool) {
 	if ip := net.ParseIP(name); ip != nil {
@@ -0,0 +1,478 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ThemeDark is the default theme.
+	ThemeDark = "dark"
+	// ThemeLight uses darker colors better visible on light background.
+	ThemeLight = "light"
+	// ThemeMonochrome uses only the default terminal colors.
+	ThemeMonochrome = "monochrome"
+)
+
+const (
+	// DefaultInterval is the default interval at which stats are polled.
+	DefaultInterval = 1 * time.Second
+	// DefaultHistory is the default time window of the kept samples.
+	DefaultHistory = 10 * time.Minute
+	// DefaultHookTimeout is the default timeout of an alert hook invocation.
+	DefaultHookTimeout = 10 * time.Second
+	// DefaultHookRateLimit is the default maximum number
+	// of the invocations of an alert hook per minute.
+	DefaultHookRateLimit = 30
+	// DefaultNodePort is the port of the remote proxy, used if the
+	// address of a remote node has no port specified.
+	DefaultNodePort = "7878"
+)
+
+type (
+	// Config represents the vpptop configuration file.
+	Config struct {
+		// StatsSocket is the path to the VPP stats socket.
+		StatsSocket string `yaml:"stats-socket"`
+		// BinapiSocket is the path to the VPP binary API socket.
+		BinapiSocket string `yaml:"binapi-socket"`
+		// Interval at which the stats are polled.
+		Interval time.Duration `yaml:"refresh-interval"`
+		// History is the time window of the samples kept
+		// for the trends, one sample per interval.
+		History time.Duration `yaml:"history"`
+		// Theme is the name of a built-in color theme or of a theme
+		// file, see ThemesDir.
+		Theme string `yaml:"theme"`
+		// DefaultTab is the name of the tab shown on start.
+		DefaultTab string `yaml:"default-tab"`
+		// HighlightChanges highlights the table cells
+		// which changed since the previous refresh.
+		HighlightChanges bool `yaml:"highlight-changes"`
+		// HumanUnits formats the values with prefixes,
+		// e.g. 1.5 Mpps, instead of raw integers.
+		HumanUnits bool `yaml:"human-units"`
+		// BinaryPrefixes formats the bytes with
+		// the binary prefixes, e.g. 1.5 KiB.
+		BinaryPrefixes bool `yaml:"binary-prefixes"`
+		// ByteRates displays the bit rates in bytes per second.
+		ByteRates bool `yaml:"byte-rates"`
+		// Tabs holds per tab settings, keyed by the tab name.
+		Tabs map[string]TabConfig `yaml:"tabs"`
+		// Nodes maps remote node names to their proxy addresses.
+		Nodes map[string]string `yaml:"nodes"`
+		// LinkSpeeds are the link speeds in bits per second, e.g. 10G,
+		// keyed by the interface name or a pattern of the names, in
+		// which * matches any characters, e.g. tap*.
+		// They override the speeds reported by VPP, which is 0 for
+		// most virtual interfaces.
+		LinkSpeeds map[string]string `yaml:"link-speeds"`
+		// PeakSampling is the interval at which the interface counters
+		// are sampled to find the peak rates within each refresh-interval,
+		// e.g. 100ms. It is shorter than the refresh-interval, 0 disables
+		// the sampling and the peaks are the rates of the refresh-interval.
+		PeakSampling time.Duration `yaml:"peak-sampling"`
+		// Alerts are the alert rules evaluated on every poll.
+		Alerts []AlertRule `yaml:"alerts"`
+		// AlertHooks are invoked when an alert is raised or cleared.
+		AlertHooks []AlertHook `yaml:"alert-hooks"`
+		// SessionFile is the path of the file where the
+		// state of the gui is saved on exit.
+		SessionFile string `yaml:"session-file"`
+	}
+
+	// AlertRule raises an alert when a metric of an entity, e.g. the
+	// drops per second of an interface, crosses the threshold.
+	AlertRule struct {
+		// Name of the alert, the metric if empty.
+		Name string `yaml:"name"`
+		// Metric is the name of the metric, e.g. interface.drops/s.
+		Metric string `yaml:"metric"`
+		// Match is the name of the entities, in which * matches
+		// any characters, e.g. Gig*. Empty matches all entities.
+		Match string `yaml:"match"`
+		// Above raises the alert when the value is above the
+		// threshold, Below when it is below. One of them is required.
+		Above *float64 `yaml:"above"`
+		Below *float64 `yaml:"below"`
+		// Clear is the value at which a raised alert clears,
+		// the threshold if not set. It keeps an alert of a
+		// value hovering around the threshold raised.
+		Clear *float64 `yaml:"clear"`
+		// For is the time the value has to stay over the
+		// threshold before the alert is raised.
+		For time.Duration `yaml:"for"`
+		// Severity is info, warning or critical, warning if empty.
+		Severity string `yaml:"severity"`
+	}
+
+	// AlertHook runs a command or posts to a URL when an alert is
+	// raised or cleared. Exactly one of Exec and URL is required.
+	AlertHook struct {
+		// Exec is the command and its arguments. The alert is passed
+		// in the VPPTOP_ALERT_* environment variables and as JSON
+		// on the standard input.
+		Exec []string `yaml:"exec"`
+		// URL receives the alert as JSON in a POST request.
+		URL string `yaml:"url"`
+		// Rules are the names of the alert rules invoking
+		// the hook, all rules if empty.
+		Rules []string `yaml:"rules"`
+		// Timeout of a single invocation, DefaultHookTimeout if zero.
+		Timeout time.Duration `yaml:"timeout"`
+		// Retries is the number of retries of a failed invocation.
+		Retries int `yaml:"retries"`
+		// RateLimit is the maximum number of invocations per minute,
+		// DefaultHookRateLimit if zero. The alerts over it are dropped.
+		RateLimit int `yaml:"rate-limit"`
+	}
+
+	// TabConfig holds the defaults for a single tab.
+	TabConfig struct {
+		// Sort is the name of the column to sort by.
+		Sort string `yaml:"sort" json:"sort"`
+		// Descending reverses the sort order.
+		Descending bool `yaml:"descending" json:"descending"`
+		// ThenBy are the columns to sort by if the values of the previous
+		// columns are equal, prefixed with "-" for descending order.
+		ThenBy []string `yaml:"then-by" json:"then-by,omitempty"`
+		// Filter is the initial filter of the tab.
+		Filter string `yaml:"filter" json:"filter"`
+		// Columns are the visible columns in the order they are displayed.
+		// Empty means all columns.
+		Columns []string `yaml:"columns" json:"columns"`
+		// Widths are the widths of the columns keyed by the column name,
+		// columns not listed keep their default width.
+		Widths map[string]int `yaml:"widths" json:"widths,omitempty"`
+		// Compact displays one entry per row, supported
+		// only by the interfaces tab.
+		Compact bool `yaml:"compact" json:"compact,omitempty"`
+		// Group displays the entries in collapsible groups, e.g.
+		// the errors by node or the sub-interfaces by their parent.
+		Group bool `yaml:"group" json:"group,omitempty"`
+		// HideIdle hides the entries without activity since the
+		// previous refresh, e.g. the error counters which did not
+		// increase. If not set, only the idle errors are hidden.
+		HideIdle *bool `yaml:"hide-idle" json:"hide-idle,omitempty"`
+		// Thresholds are the values of the columns keyed by the column
+		// name, above which the cells are highlighted. They are
+		// not part of the saved session.
+		Thresholds map[string]float64 `yaml:"thresholds" json:"-"`
+	}
+)
+
+// Default returns the configuration used when no file is present.
+func Default() *Config {
+	return &Config{
+		Interval: DefaultInterval,
+		History:  DefaultHistory,
+		Theme:    ThemeDark,
+		Tabs:     make(map[string]TabConfig),
+		Nodes:    make(map[string]string),
+	}
+}
+
+// DefaultPath returns the path of the configuration file
+// based on the XDG base directory specification.
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "vpptop", "config.yaml")
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".config", "vpptop", "config.yaml")
+	}
+	return ""
+}
+
+// ThemesDir returns the directory where the theme files are looked up.
+func ThemesDir() string {
+	path := DefaultPath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), "themes")
+}
+
+// Load reads the configuration from the file at path. If the path is
+// empty the DefaultPath is used, in which case a missing file is not an error.
+func Load(path string) (*Config, error) {
+	optional := path == ""
+	if optional {
+		path = DefaultPath()
+	}
+
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if optional && os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("error occurred while reading config: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(b, cfg); err != nil {
+		return nil, fmt.Errorf("error occurred while parsing config %s: %v", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Tab returns the settings for the tab with the given name.
+// Tab names are case insensitive.
+func (c *Config) Tab(name string) TabConfig {
+	for k, v := range c.Tabs {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return TabConfig{}
+}
+
+// SessionPath returns the path of the session file.
+func (c *Config) SessionPath() string {
+	if c.SessionFile != "" {
+		return c.SessionFile
+	}
+	return DefaultSessionPath()
+}
+
+// NodeAddr returns the proxy address configured for the remote node.
+func (c *Config) NodeAddr(name string) (string, bool) {
+	addr, ok := c.Nodes[name]
+	if !ok {
+		return "", false
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, DefaultNodePort)
+	}
+	return addr, true
+}
+
+// alert severities.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Threshold returns the threshold of the rule, and whether the
+// alert is raised above it or below it.
+func (r *AlertRule) Threshold() (threshold float64, above bool) {
+	if r.Above != nil {
+		return *r.Above, true
+	}
+	return *r.Below, false
+}
+
+// ClearValue returns the value at which a raised alert clears.
+func (r *AlertRule) ClearValue() float64 {
+	if r.Clear != nil {
+		return *r.Clear
+	}
+	threshold, _ := r.Threshold()
+	return threshold
+}
+
+// validate checks the rule and fills in the defaults.
+func (r *AlertRule) validate() error {
+	if r.Metric == "" {
+		return fmt.Errorf("missing metric")
+	}
+	if (r.Above == nil) == (r.Below == nil) {
+		return fmt.Errorf("%s: exactly one of above and below is required", r.Metric)
+	}
+	if r.For < 0 {
+		return fmt.Errorf("%s: negative for: %v", r.Metric, r.For)
+	}
+	threshold, above := r.Threshold()
+	if clear := r.ClearValue(); above && clear > threshold || !above && clear < threshold {
+		return fmt.Errorf("%s: clear %v is beyond the threshold %v", r.Metric, clear, threshold)
+	}
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("%s: unknown severity %q", r.Metric, r.Severity)
+	}
+	if r.Name == "" {
+		r.Name = r.Metric
+	}
+	return nil
+}
+
+// validate checks the hook and fills in the defaults.
+func (h *AlertHook) validate() error {
+	if (len(h.Exec) == 0) == (h.URL == "") {
+		return fmt.Errorf("exactly one of exec and url is required")
+	}
+	if h.URL != "" {
+		u, err := url.Parse(h.URL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("url %q is not a http(s) url", h.URL)
+		}
+	}
+	if h.Timeout < 0 || h.Retries < 0 || h.RateLimit < 0 {
+		return fmt.Errorf("negative timeout, retries or rate-limit")
+	}
+	if h.Timeout == 0 {
+		h.Timeout = DefaultHookTimeout
+	}
+	if h.RateLimit == 0 {
+		h.RateLimit = DefaultHookRateLimit
+	}
+	return nil
+}
+
+// LinkSpeed returns the link speed in bits per second configured for
+// the interface. The name takes precedence over the patterns, which
+// are tried in the lexical order.
+func (c *Config) LinkSpeed(name string) (uint64, bool) {
+	if speed, ok := c.LinkSpeeds[name]; ok {
+		return parseSpeed(speed)
+	}
+	patterns := make([]string, 0, len(c.LinkSpeeds))
+	for pattern := range c.LinkSpeeds {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if MatchName(pattern, name) {
+			return parseSpeed(c.LinkSpeeds[pattern])
+		}
+	}
+	return 0, false
+}
+
+// MatchName returns true if the name matches the pattern,
+// in which * matches any characters, including a slash.
+func MatchName(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	last := parts[len(parts)-1]
+	return len(name) >= len(last) && strings.HasSuffix(name, last)
+}
+
+// speedSuffixes are the multipliers of the link speed suffixes.
+var speedSuffixes = map[string]float64{
+	"":  1,
+	"k": 1e3,
+	"K": 1e3,
+	"M": 1e6,
+	"G": 1e9,
+	"T": 1e12,
+}
+
+// parseSpeed parses a link speed with an optional suffix
+// and an optional bps unit, e.g. 100M or 25Gbps.
+func parseSpeed(s string) (uint64, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "bps")
+	end := len(s)
+	if end > 0 && !unicode.IsDigit(rune(s[end-1])) {
+		end--
+	}
+	m, ok := speedSuffixes[s[end:]]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s[:end]), 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return uint64(v * m), true
+}
+
+// Validate checks the values and fills in defaults for the missing ones.
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("negative refresh-interval: %v", c.Interval)
+	}
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.History < 0 {
+		return fmt.Errorf("negative history: %v", c.History)
+	}
+	if c.History == 0 {
+		c.History = DefaultHistory
+	}
+	if c.Theme == "" {
+		c.Theme = ThemeDark
+	}
+	if c.Tabs == nil {
+		c.Tabs = make(map[string]TabConfig)
+	}
+	if c.Nodes == nil {
+		c.Nodes = make(map[string]string)
+	}
+	if c.PeakSampling < 0 {
+		return fmt.Errorf("negative peak-sampling: %v", c.PeakSampling)
+	}
+	if c.PeakSampling >= c.Interval {
+		return fmt.Errorf("peak-sampling %v is not shorter than refresh-interval %v", c.PeakSampling, c.Interval)
+	}
+	for pattern, speed := range c.LinkSpeeds {
+		if _, ok := parseSpeed(speed); !ok {
+			return fmt.Errorf("invalid link speed of %s: %q", pattern, speed)
+		}
+	}
+	for i := range c.Alerts {
+		if err := c.Alerts[i].validate(); err != nil {
+			return fmt.Errorf("invalid alert rule %d: %v", i+1, err)
+		}
+	}
+	for i := range c.AlertHooks {
+		if err := c.AlertHooks[i].validate(); err != nil {
+			return fmt.Errorf("invalid alert hook %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
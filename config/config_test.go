@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vpptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		content string
+		want    *Config
+		wantErr bool
+	}{
+		{content: "", want: Default()},
+		{
+			content: `
+stats-socket: /tmp/stats.sock
+refresh-interval: 500ms
+history: 5m
+theme: light
+default-tab: nodes
+highlight-changes: true
+tabs:
+  nodes:
+    sort: Clocks
+    descending: true
+    filter: ip4
+    columns: [NodeName, Clocks]
+    widths: {NodeName: 30}
+    thresholds: {Clocks: 1e6}
+nodes:
+  worker: 10.0.0.1
+link-speeds:
+  tap*: 10G
+`,
+			want: &Config{
+				StatsSocket:      "/tmp/stats.sock",
+				Interval:         500 * time.Millisecond,
+				History:          5 * time.Minute,
+				Theme:            ThemeLight,
+				DefaultTab:       "nodes",
+				HighlightChanges: true,
+				Tabs: map[string]TabConfig{
+					"nodes": {
+						Sort: "Clocks", Descending: true, Filter: "ip4", Columns: []string{"NodeName", "Clocks"},
+						Widths: map[string]int{"NodeName": 30}, Thresholds: map[string]float64{"Clocks": 1e6},
+					},
+				},
+				Nodes:      map[string]string{"worker": "10.0.0.1"},
+				LinkSpeeds: map[string]string{"tap*": "10G"},
+			},
+		},
+		{content: "theme: \"\"", want: Default()},
+		{content: "refresh-interval: -1s", wantErr: true},
+		{content: "history: -1m", wantErr: true},
+		{content: "unknown-key: 1", wantErr: true},
+		{content: "link-speeds: {tap0: fast}", wantErr: true},
+		{content: "alerts: [{metric: node.clocks}]", wantErr: true},
+		{content: "alerts: [{metric: node.clocks, above: 1, below: 2}]", wantErr: true},
+		{content: "alerts: [{metric: node.clocks, above: 10, clear: 20}]", wantErr: true},
+		{content: "alerts: [{metric: node.clocks, above: 10, severity: fatal}]", wantErr: true},
+		{content: "alert-hooks: [{}]", wantErr: true},
+		{content: "alert-hooks: [{exec: [page], url: \"http://localhost\"}]", wantErr: true},
+		{content: "alert-hooks: [{url: \"localhost:9000\"}]", wantErr: true},
+	}
+
+	for i, test := range tests {
+		path := filepath.Join(dir, "config.yaml")
+		if err := ioutil.WriteFile(path, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Load(path)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: got:%+v; want:%+v", i, got, test.want)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("expected error for missing config file")
+	}
+}
+
+func TestConfig_NodeAddr(t *testing.T) {
+	cfg := &Config{Nodes: map[string]string{
+		"a": "10.0.0.1",
+		"b": "10.0.0.2:9191",
+		"c": "fd00::1",
+	}}
+	tests := []struct {
+		name  string
+		want  string
+		found bool
+	}{
+		{name: "a", want: "10.0.0.1:7878", found: true},
+		{name: "b", want: "10.0.0.2:9191", found: true},
+		{name: "c", want: "[fd00::1]:7878", found: true},
+		{name: "d", want: "", found: false},
+	}
+	for _, test := range tests {
+		got, found := cfg.NodeAddr(test.name)
+		if got != test.want || found != test.found {
+			t.Errorf("Error occured got:%v,%v; want:%v,%v", got, found, test.want, test.found)
+		}
+	}
+}
+
+func TestConfig_LinkSpeed(t *testing.T) {
+	cfg := &Config{LinkSpeeds: map[string]string{
+		"tap0":    "100M",
+		"tap*":    "10G",
+		"memif*":  "25Gbps",
+		"vxlan_*": "1.5 G",
+		"*-pf*":   "40G",
+	}}
+	tests := []struct {
+		name  string
+		want  uint64
+		found bool
+	}{
+		{name: "tap0", want: 100e6, found: true},
+		{name: "tap1", want: 10e9, found: true},
+		{name: "memif0/0", want: 25e9, found: true},
+		{name: "vxlan_tunnel0", want: 1.5e9, found: true},
+		{name: "eth-pf0", want: 40e9, found: true},
+		{name: "GigabitEthernet0/8/0", want: 0, found: false},
+	}
+	for _, test := range tests {
+		got, found := cfg.LinkSpeed(test.name)
+		if got != test.want || found != test.found {
+			t.Errorf("Error occured got:%v,%v; want:%v,%v", got, found, test.want, test.found)
+		}
+	}
+}
+
+func TestSaveSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vpptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state", "session.json")
+
+	local := Session{Tab: "Nodes", Tabs: map[string]TabSession{
+		"Nodes": {TabConfig: TabConfig{Sort: "Clocks", Descending: true, Filter: "ip4"}, Offset: 10, Selected: 2},
+	}}
+	remote := Session{Tab: "Errors", Tabs: map[string]TabSession{}}
+
+	if err := SaveSession(path, "/run/vpp/stats.sock", local); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSession(path, "worker-1", remote); err != nil {
+		t.Fatal(err)
+	}
+
+	for endpoint, want := range map[string]Session{"/run/vpp/stats.sock": local, "worker-1": remote} {
+		got, found, err := LoadSession(path, endpoint)
+		if err != nil || !found {
+			t.Fatalf("session for %s not loaded: %v", endpoint, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Error occured got:%+v; want:%+v", got, want)
+		}
+	}
+	if _, found, _ := LoadSession(path, "unknown"); found {
+		t.Errorf("unexpected session for unknown endpoint")
+	}
+	// the temporary files are renamed to the session file.
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Error occured got:%v; want:%v", len(files), 1)
+	}
+}
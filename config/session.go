@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type (
+	// Session is the state of the gui saved on exit,
+	// to be restored on the next start.
+	Session struct {
+		// Tab is the name of the active tab.
+		Tab string `json:"tab"`
+		// Tabs holds the state of each tab, keyed by the tab name.
+		Tabs map[string]TabSession `json:"tabs"`
+	}
+
+	// TabSession is the saved state of a single tab.
+	TabSession struct {
+		TabConfig
+		// Offset is the first row displayed in the table.
+		Offset int `json:"offset"`
+		// Selected is the selected row relative to the offset.
+		Selected int `json:"selected"`
+	}
+)
+
+// DefaultSessionPath returns the path of the session file
+// based on the XDG base directory specification.
+func DefaultSessionPath() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "vpptop", "session.json")
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".local", "state", "vpptop", "session.json")
+	}
+	return ""
+}
+
+// LoadSession returns the session saved for the endpoint in the file at path.
+// The returned bool is false if no session was saved.
+func LoadSession(path, endpoint string) (Session, bool, error) {
+	sessions, err := readSessions(path)
+	if err != nil {
+		return Session{}, false, err
+	}
+	s, ok := sessions[endpoint]
+	return s, ok, nil
+}
+
+// SaveSession saves the session for the endpoint to the file at path,
+// keeping the sessions of other endpoints.
+func SaveSession(path, endpoint string, s Session) error {
+	sessions, err := readSessions(path)
+	if err != nil {
+		return err
+	}
+	sessions[endpoint] = s
+
+	b, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error occurred while creating session dir: %v", err)
+	}
+	if err := writeFile(path, b); err != nil {
+		return fmt.Errorf("error occurred while writing session: %v", err)
+	}
+	return nil
+}
+
+// writeFile writes b to a temporary file in the directory of path
+// and renames it to path, so the file is never left half written.
+func writeFile(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// readSessions reads all sessions from the file at path.
+// A missing file yields no sessions.
+func readSessions(path string) (map[string]Session, error) {
+	sessions := make(map[string]Session)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sessions, nil
+		}
+		return nil, fmt.Errorf("error occurred while reading session: %v", err)
+	}
+	if err := json.Unmarshal(b, &sessions); err != nil {
+		return nil, fmt.Errorf("error occurred while parsing session %s: %v", path, err)
+	}
+	return sessions, nil
+}
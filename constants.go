@@ -1,71 +0,0 @@
-/*
- * Copyright (c) 2019 PANTHEON.tech.
- *
- * Licensed under the Apache License, Version 2.0 (the "License");
- * you may not use this file except in compliance with the License.
- * You may obtain a copy of the License at:
- *
- *     http://www.apache.org/licenses/LICENSE-2.0
- *
- * Unless required by applicable law or agreed to in writing, software
- * distributed under the License is distributed on an "AS IS" BASIS,
- * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- * See the License for the specific language governing permissions and
- * limitations under the License.
- */
-
-package main
-
-// NoColumn indicates no column should be used.
-const NoColumn = -1
-
-// Mapped node stats fields
-const (
-	NodeStatNodeName = iota
-	NodeStatNodeIndex
-	NodeStatNodeClocks
-	NodeStatNodeVectors
-	NodeStatNodeCalls
-	NodeStatNodeSuspends
-	NodeStatNodeVC
-)
-
-// Mapped interface stats fields
-const (
-	IfaceStatIfaceName = iota
-	IfaceStatIfaceIdx
-	IfaceStatIfaceState
-	IfaceStatIfaceMTUL3
-	IfaceStatIfaceMTUIP4
-	IfaceStatIfaceMTUIP6
-	IfaceStatIfaceMTUMPLS
-	IfaceStatIfaceRxPackets
-	IfaceStatIfaceRxBytes
-	IfaceStatIfaceRxErrors
-	IfaceStatIfaceRxUnicastPackets
-	IfaceStatIfaceRxUnicastBytes
-	IfaceStatIfaceRxMulticastPackets
-	IfaceStatIfaceRxMulticastBytes
-	IfaceStatIfaceRxBroadcastPackets
-	IfaceStatIfaceRxBroadcastBytes
-	IfaceStatIfaceTxPackets
-	IfaceStatIfaceTxBytes
-	IfaceStatIfaceTxErrors
-	IfaceStatIfaceTxUnicastMissPackets
-	IfaceStatIfaceTxUnicastMissBytes
-	IfaceStatIfaceTxMulticastPackets
-	IfaceStatIfaceTxMulticastBytes
-	IfaceStatIfaceTxBroadcastPackets
-	IfaceStatIfaceTxBroadcastBytes
-	IfaceStatIfaceDrops
-	IfaceStatIfacePunts
-	IfaceStatIfaceIP4
-	IfaceStatIfaceIP6
-)
-
-// Mapped error stats fields.
-const (
-	ErrorStatErrorCounter = iota
-	ErrorStatErrorNodeName
-	ErrorStatErrorReason
-)
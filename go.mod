@@ -14,6 +14,7 @@ require (
 	go.ligato.io/vpp-agent/v2 v2.5.0-alpha.0.20191104095948-6ebd4de70cd9
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45 // indirect
 	golang.org/x/sys v0.0.0-20191104094858-e8c54fb511f6 // indirect
+	gopkg.in/yaml.v2 v2.2.4
 	k8s.io/api v0.0.0-20191016225839-816a9b7df678
 	k8s.io/apimachinery v0.0.0-20191017185446-6e68a40eebf9
 	k8s.io/client-go v0.0.0-20190620085101-78d2af792bab
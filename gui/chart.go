@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gui
+
+import (
+	"fmt"
+
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
+)
+
+// ShowChart displays the chart of the series instead of the main view
+// until it's closed.
+func (w *TermWindow) ShowChart(title, unit string, series []xtui.Series) {
+	w.chart.Title = fmt.Sprintf("%s (%v to close, %v/%v to zoom)", title, KeyCancel, KeyZoomIn, KeyZoomOut)
+	w.chart.Unit = unit
+	w.chart.SetSeries(series)
+	w.chart.ResetZoom()
+	w.view = chart
+	w.keybindings = w.chartKeybindings()
+}
+
+// UpdateChart replaces the series of the displayed chart.
+// It returns false if the chart was closed.
+func (w *TermWindow) UpdateChart(series []xtui.Series) bool {
+	if w.view != chart {
+		return false
+	}
+	w.chart.SetSeries(series)
+	return true
+}
+
+// handleZoom zooms the time window of the chart in or out.
+func (w *TermWindow) handleZoom(event Event) {
+	w.chart.Zoom(event.Payload.(string) == KeyZoomIn)
+}
@@ -17,77 +17,406 @@
 package gui
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	tui "github.com/gizak/termui/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// Theme holds the colors of the gui components.
+type Theme struct {
+	// Text is the color of the text and borders.
+	Text tui.Color
+
+	TabActive   tui.Style
+	TabInactive tui.Style
+	// Header is the style of the table headers.
+	Header tui.Style
+	// SelectedRow is the style of the selected table row.
+	SelectedRow tui.Style
+	// Match is the style of the table rows matching the search.
+	Match             tui.Style
+	Notification      tui.Style
+	Filter            tui.Style
+	SortPanel         tui.Style
+	SortPanelSelected tui.Style
+
+	// Change styles are used to highlight the table cells which
+	// changed, error counters which increased and values over
+	// their threshold.
+	Changed       tui.Style
+	Increased     tui.Style
+	OverThreshold tui.Style
+
+	// Severity styles are used to highlight alerts.
+	SeverityInfo     tui.Style
+	SeverityWarning  tui.Style
+	SeverityCritical tui.Style
+}
+
+// colors from the 256-color palette used by the built-in themes.
+const (
+	colorBrightWhite  tui.Color = 15
+	colorDeepBlue     tui.Color = 25
+	colorBrightCyan   tui.Color = 51
+	colorSkyBlue      tui.Color = 74
+	colorDarkOrange   tui.Color = 130
+	colorVermillion   tui.Color = 166
+	colorBrightRed    tui.Color = 196
+	colorOrange       tui.Color = 214
+	colorBrightYellow tui.Color = 226
+	colorYellow       tui.Color = 227
 )
 
-// gui lightTheme settings.
+// built-in themes.
 var (
-	lightTheme = tui.RootTheme{
-		Default: tui.NewStyle(tui.ColorBlack),
-
-		Block: tui.BlockTheme{
-			Title:  tui.NewStyle(tui.ColorBlack),
-			Border: tui.NewStyle(tui.ColorBlack),
-		},
-
-		BarChart: tui.BarChartTheme{
-			Bars:   tui.StandardColors,
-			Nums:   tui.StandardStyles,
-			Labels: tui.StandardStyles,
-		},
-
-		Paragraph: tui.ParagraphTheme{
-			Text: tui.NewStyle(tui.ColorBlack),
-		},
-
-		PieChart: tui.PieChartTheme{
-			Slices: tui.StandardColors,
-		},
-
-		List: tui.ListTheme{
-			Text: tui.NewStyle(tui.ColorBlack),
-		},
-
-		StackedBarChart: tui.StackedBarChartTheme{
-			Bars:   tui.StandardColors,
-			Nums:   tui.StandardStyles,
-			Labels: tui.StandardStyles,
-		},
-
-		Gauge: tui.GaugeTheme{
-			Bar:   tui.ColorBlack,
-			Label: tui.NewStyle(tui.ColorBlack),
-		},
-
-		Sparkline: tui.SparklineTheme{
-			Title: tui.NewStyle(tui.ColorBlack),
-			Line:  tui.ColorBlack,
-		},
-
-		Plot: tui.PlotTheme{
-			Lines: tui.StandardColors,
-			Axes:  tui.ColorBlack,
-		},
-
-		Table: tui.TableTheme{
-			Text: tui.NewStyle(tui.ColorBlack),
-		},
-
-		Tab: tui.TabTheme{
-			Active:   tui.NewStyle(tui.ColorRed),
-			Inactive: tui.NewStyle(tui.ColorBlack),
-		},
-	}
-
-	textStyle        = tui.ColorWhite
-	filterBackground = tui.ColorBlue
+	darkTheme = Theme{
+		Text:              tui.ColorWhite,
+		TabActive:         tui.NewStyle(tui.ColorRed),
+		TabInactive:       tui.NewStyle(tui.ColorWhite),
+		Header:            tui.NewStyle(tui.ColorWhite, tui.ColorRed),
+		SelectedRow:       tui.NewStyle(tui.ColorBlack, tui.ColorGreen, tui.ModifierBold),
+		Match:             tui.NewStyle(tui.ColorBlack, tui.ColorYellow),
+		Notification:      tui.NewStyle(tui.ColorWhite, tui.ColorBlue, tui.ModifierBold),
+		Filter:            tui.NewStyle(tui.ColorWhite, tui.ColorBlue, tui.ModifierBold),
+		SortPanel:         tui.NewStyle(tui.ColorWhite, tui.ColorBlue, tui.ModifierBold),
+		SortPanelSelected: tui.NewStyle(tui.ColorYellow, tui.ColorBlue, tui.ModifierBold),
+		Changed:           tui.NewStyle(tui.ColorCyan),
+		Increased:         tui.NewStyle(tui.ColorRed, tui.ColorClear, tui.ModifierBold),
+		OverThreshold:     tui.NewStyle(tui.ColorMagenta, tui.ColorClear, tui.ModifierBold),
+		SeverityInfo:      tui.NewStyle(tui.ColorCyan),
+		SeverityWarning:   tui.NewStyle(tui.ColorYellow, tui.ColorClear, tui.ModifierBold),
+		SeverityCritical:  tui.NewStyle(tui.ColorWhite, tui.ColorRed, tui.ModifierBold),
+	}
+
+	lightTheme = Theme{
+		Text:              tui.ColorBlack,
+		TabActive:         tui.NewStyle(tui.ColorRed),
+		TabInactive:       tui.NewStyle(tui.ColorBlack),
+		Header:            tui.NewStyle(tui.ColorWhite, tui.ColorRed),
+		SelectedRow:       tui.NewStyle(tui.ColorBlack, tui.ColorGreen, tui.ModifierBold),
+		Match:             tui.NewStyle(tui.ColorBlack, tui.ColorYellow),
+		Notification:      tui.NewStyle(tui.ColorBlack, tui.ColorBlue, tui.ModifierBold),
+		Filter:            tui.NewStyle(tui.ColorBlack, tui.ColorCyan, tui.ModifierBold),
+		SortPanel:         tui.NewStyle(tui.ColorBlack, tui.ColorBlue, tui.ModifierBold),
+		SortPanelSelected: tui.NewStyle(tui.ColorYellow, tui.ColorBlue, tui.ModifierBold),
+		Changed:           tui.NewStyle(tui.ColorBlue),
+		Increased:         tui.NewStyle(tui.ColorRed, tui.ColorClear, tui.ModifierBold),
+		OverThreshold:     tui.NewStyle(tui.ColorMagenta, tui.ColorClear, tui.ModifierBold),
+		SeverityInfo:      tui.NewStyle(tui.ColorBlue),
+		SeverityWarning:   tui.NewStyle(colorDarkOrange, tui.ColorClear, tui.ModifierBold),
+		SeverityCritical:  tui.NewStyle(tui.ColorWhite, tui.ColorRed, tui.ModifierBold),
+	}
+
+	// highContrastTheme uses bright 256-palette colors.
+	highContrastTheme = Theme{
+		Text:              colorBrightWhite,
+		TabActive:         tui.NewStyle(tui.ColorBlack, colorBrightYellow, tui.ModifierBold),
+		TabInactive:       tui.NewStyle(colorBrightWhite),
+		Header:            tui.NewStyle(tui.ColorBlack, colorBrightWhite, tui.ModifierBold),
+		SelectedRow:       tui.NewStyle(tui.ColorBlack, colorBrightYellow, tui.ModifierBold),
+		Match:             tui.NewStyle(tui.ColorBlack, colorBrightCyan, tui.ModifierBold),
+		Notification:      tui.NewStyle(tui.ColorBlack, colorBrightCyan, tui.ModifierBold),
+		Filter:            tui.NewStyle(tui.ColorBlack, colorBrightWhite, tui.ModifierBold),
+		SortPanel:         tui.NewStyle(colorBrightWhite, tui.ColorBlack, tui.ModifierBold),
+		SortPanelSelected: tui.NewStyle(tui.ColorBlack, colorBrightYellow, tui.ModifierBold),
+		Changed:           tui.NewStyle(colorBrightCyan),
+		Increased:         tui.NewStyle(colorBrightRed, tui.ColorClear, tui.ModifierBold),
+		OverThreshold:     tui.NewStyle(colorBrightYellow, tui.ColorClear, tui.ModifierBold),
+		SeverityInfo:      tui.NewStyle(colorBrightCyan, tui.ColorClear, tui.ModifierBold),
+		SeverityWarning:   tui.NewStyle(colorBrightYellow, tui.ColorClear, tui.ModifierBold),
+		SeverityCritical:  tui.NewStyle(colorBrightWhite, colorBrightRed, tui.ModifierBold),
+	}
+
+	// colorblindTheme is based on the Okabe-Ito palette, which
+	// stays distinguishable for the common color vision deficiencies.
+	colorblindTheme = Theme{
+		Text:              tui.ColorWhite,
+		TabActive:         tui.NewStyle(colorOrange, tui.ColorClear, tui.ModifierBold),
+		TabInactive:       tui.NewStyle(tui.ColorWhite),
+		Header:            tui.NewStyle(tui.ColorBlack, colorSkyBlue, tui.ModifierBold),
+		SelectedRow:       tui.NewStyle(tui.ColorBlack, colorOrange, tui.ModifierBold),
+		Match:             tui.NewStyle(tui.ColorBlack, colorYellow),
+		Notification:      tui.NewStyle(tui.ColorBlack, colorSkyBlue, tui.ModifierBold),
+		Filter:            tui.NewStyle(tui.ColorBlack, colorSkyBlue, tui.ModifierBold),
+		SortPanel:         tui.NewStyle(tui.ColorWhite, colorDeepBlue, tui.ModifierBold),
+		SortPanelSelected: tui.NewStyle(colorYellow, colorDeepBlue, tui.ModifierBold),
+		Changed:           tui.NewStyle(colorSkyBlue),
+		Increased:         tui.NewStyle(colorVermillion, tui.ColorClear, tui.ModifierBold),
+		OverThreshold:     tui.NewStyle(colorYellow, tui.ColorClear, tui.ModifierBold),
+		SeverityInfo:      tui.NewStyle(colorSkyBlue),
+		SeverityWarning:   tui.NewStyle(colorYellow, tui.ColorClear, tui.ModifierBold),
+		SeverityCritical:  tui.NewStyle(tui.ColorBlack, colorVermillion, tui.ModifierBold),
+	}
+
+	// monochromeTheme uses only the default terminal colors, it's used
+	// if the NO_COLOR environment variable is set.
+	monochromeTheme = Theme{
+		Text:              tui.ColorClear,
+		TabActive:         tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		TabInactive:       tui.NewStyle(tui.ColorClear),
+		Header:            tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierBold|tui.ModifierUnderline),
+		SelectedRow:       tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		Match:             tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierUnderline),
+		Notification:      tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		Filter:            tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		SortPanel:         tui.NewStyle(tui.ColorClear),
+		SortPanelSelected: tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		Changed:           tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierBold),
+		Increased:         tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierBold|tui.ModifierUnderline),
+		OverThreshold:     tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierReverse),
+		SeverityInfo:      tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierUnderline),
+		SeverityWarning:   tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierBold),
+		SeverityCritical:  tui.NewStyle(tui.ColorClear, tui.ColorClear, tui.ModifierBold|tui.ModifierReverse),
+	}
 )
 
-// SetLightTheme changes the basic colors of the tui lib to
-// darker colors which are better visible on lighter background.
-// This should be called before any tui widget created.
-func SetLightTheme() {
-	textStyle = tui.ColorBlack
-	filterBackground = tui.ColorCyan
-	tui.Theme = lightTheme
+// Themes are the built-in themes, keyed by name.
+var Themes = map[string]*Theme{
+	"dark":          &darkTheme,
+	"light":         &lightTheme,
+	"high-contrast": &highContrastTheme,
+	"colorblind":    &colorblindTheme,
+	"monochrome":    &monochromeTheme,
+}
+
+// theme is the theme used by the gui.
+var theme = &darkTheme
+
+// CurrentTheme returns the theme used by the gui.
+func CurrentTheme() *Theme {
+	return theme
+}
+
+// SetTheme changes the colors of the tui lib to the colors of the theme.
+// Widgets created after this call use the theme, already created widgets
+// are changed by TermWindow.SetTheme.
+func SetTheme(t *Theme) {
+	theme = t
+
+	root := tui.Theme
+	root.Default = tui.NewStyle(t.Text)
+	root.Block.Title = tui.NewStyle(t.Text)
+	root.Block.Border = tui.NewStyle(t.Text)
+	root.Paragraph.Text = tui.NewStyle(t.Text)
+	root.List.Text = tui.NewStyle(t.Text)
+	root.Table.Text = tui.NewStyle(t.Text)
+	root.Tab.Active = t.TabActive
+	root.Tab.Inactive = t.TabInactive
+	root.Gauge.Bar = t.Text
+	root.Gauge.Label = tui.NewStyle(t.Text)
+	root.Sparkline.Title = tui.NewStyle(t.Text)
+	root.Sparkline.Line = t.Text
+	root.Plot.Axes = t.Text
+	tui.Theme = root
+}
+
+// LoadTheme returns the built-in theme with the given name, or loads the theme
+// from a file. The name is either a path to the file, or the name of the file
+// without the .yaml extension in one of the dirs.
+func LoadTheme(name string, dirs ...string) (*Theme, error) {
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+	path := name
+	if _, err := os.Stat(path); err != nil {
+		for _, dir := range dirs {
+			p := filepath.Join(dir, name+".yaml")
+			if _, err := os.Stat(p); err == nil {
+				path = p
+				break
+			}
+		}
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unknown theme %q: %v", name, err)
+	}
+	return parseTheme(b)
+}
+
+// themeFile is the format of the theme file. Each color is either a name
+// (red, blue, ..), an index into the 256-color palette or a #rrggbb value,
+// which is approximated by the closest color of the 256-color palette.
+type themeFile struct {
+	// Base is the name of the built-in theme which is
+	// used for the colors not set in the file.
+	Base              string     `yaml:"base"`
+	Text              string     `yaml:"text"`
+	TabActive         *styleSpec `yaml:"tab-active"`
+	TabInactive       *styleSpec `yaml:"tab-inactive"`
+	Header            *styleSpec `yaml:"header"`
+	SelectedRow       *styleSpec `yaml:"selected-row"`
+	Match             *styleSpec `yaml:"match"`
+	Notification      *styleSpec `yaml:"notification"`
+	Filter            *styleSpec `yaml:"filter"`
+	SortPanel         *styleSpec `yaml:"sort-panel"`
+	SortPanelSelected *styleSpec `yaml:"sort-panel-selected"`
+	Changed           *styleSpec `yaml:"changed"`
+	Increased         *styleSpec `yaml:"increased"`
+	OverThreshold     *styleSpec `yaml:"over-threshold"`
+	Severity          struct {
+		Info     *styleSpec `yaml:"info"`
+		Warning  *styleSpec `yaml:"warning"`
+		Critical *styleSpec `yaml:"critical"`
+	} `yaml:"severity"`
+}
+
+// styleSpec is a style in the theme file.
+type styleSpec struct {
+	Fg  string `yaml:"fg"`
+	Bg  string `yaml:"bg"`
+	Mod string `yaml:"mod"`
 }
+
+// parseTheme parses the theme file.
+func parseTheme(b []byte) (*Theme, error) {
+	var f themeFile
+	if err := yaml.UnmarshalStrict(b, &f); err != nil {
+		return nil, fmt.Errorf("error occurred while parsing theme: %v", err)
+	}
+	if f.Base == "" {
+		f.Base = "dark"
+	}
+	base, ok := Themes[f.Base]
+	if !ok {
+		return nil, fmt.Errorf("unknown base theme: %q", f.Base)
+	}
+	t := *base
+
+	if f.Text != "" {
+		c, err := parseColor(f.Text)
+		if err != nil {
+			return nil, err
+		}
+		t.Text = c
+	}
+	styles := []struct {
+		spec  *styleSpec
+		style *tui.Style
+	}{
+		{spec: f.TabActive, style: &t.TabActive},
+		{spec: f.TabInactive, style: &t.TabInactive},
+		{spec: f.Header, style: &t.Header},
+		{spec: f.SelectedRow, style: &t.SelectedRow},
+		{spec: f.Match, style: &t.Match},
+		{spec: f.Notification, style: &t.Notification},
+		{spec: f.Filter, style: &t.Filter},
+		{spec: f.SortPanel, style: &t.SortPanel},
+		{spec: f.SortPanelSelected, style: &t.SortPanelSelected},
+		{spec: f.Changed, style: &t.Changed},
+		{spec: f.Increased, style: &t.Increased},
+		{spec: f.OverThreshold, style: &t.OverThreshold},
+		{spec: f.Severity.Info, style: &t.SeverityInfo},
+		{spec: f.Severity.Warning, style: &t.SeverityWarning},
+		{spec: f.Severity.Critical, style: &t.SeverityCritical},
+	}
+	for _, s := range styles {
+		if s.spec == nil {
+			continue
+		}
+		style, err := s.spec.parse()
+		if err != nil {
+			return nil, err
+		}
+		*s.style = style
+	}
+	return &t, nil
+}
+
+// parse converts the styleSpec to tui.Style,
+// unset colors are set to the default terminal colors.
+func (s *styleSpec) parse() (tui.Style, error) {
+	style := tui.StyleClear
+	var err error
+	if s.Fg != "" {
+		if style.Fg, err = parseColor(s.Fg); err != nil {
+			return style, err
+		}
+	}
+	if s.Bg != "" {
+		if style.Bg, err = parseColor(s.Bg); err != nil {
+			return style, err
+		}
+	}
+	for _, mod := range strings.FieldsFunc(s.Mod, func(r rune) bool { return r == '|' || r == ',' || r == ' ' }) {
+		switch mod {
+		case "bold":
+			style.Modifier |= tui.ModifierBold
+		case "underline":
+			style.Modifier |= tui.ModifierUnderline
+		case "reverse":
+			style.Modifier |= tui.ModifierReverse
+		default:
+			return style, fmt.Errorf("unknown modifier: %q", mod)
+		}
+	}
+	return style, nil
+}
+
+// parseColor parses a color name, a 256-color palette index or a #rrggbb value.
+func parseColor(s string) (tui.Color, error) {
+	if c, ok := tui.StyleParserColorMap[s]; ok {
+		return c, nil
+	}
+	if strings.HasPrefix(s, "#") {
+		rgb, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil || len(s) != 7 {
+			return 0, fmt.Errorf("invalid color: %q", s)
+		}
+		return rgbTo256(int(rgb>>16&0xff), int(rgb>>8&0xff), int(rgb&0xff)), nil
+	}
+	c, err := strconv.Atoi(s)
+	if err != nil || c < 0 || c > 255 {
+		return 0, fmt.Errorf("invalid color: %q", s)
+	}
+	return tui.Color(c), nil
+}
+
+// rgbTo256 returns the closest color from the 256-color palette,
+// either from the 6x6x6 color cube or the grayscale ramp.
+func rgbTo256(r, g, b int) tui.Color {
+	levels := []int{0, 95, 135, 175, 215, 255}
+	nearest := func(v int) int {
+		best := 0
+		for i, l := range levels {
+			if abs(v-l) < abs(v-levels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearest(r), nearest(g), nearest(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := sq(r-levels[ri]) + sq(g-levels[gi]) + sq(b-levels[bi])
+
+	// grayscale ramp 232-255 goes from 8 to 238 in steps of 10.
+	step := ((r+g+b)/3 - 8 + 5) / 10
+	if step < 0 {
+		step = 0
+	}
+	if step > 23 {
+		step = 23
+	}
+	gray := 8 + 10*step
+	grayDist := sq(r-gray) + sq(g-gray) + sq(b-gray)
+
+	if grayDist < cubeDist {
+		return tui.Color(232 + step)
+	}
+	return tui.Color(cube)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sq(x int) int { return x * x }
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gui
+
+import (
+	"fmt"
+)
+
+// handleColumnMenu changes the main view to the column picker,
+// if the view supports it. The displayed columns are listed first
+// in the order they are displayed, followed by the hidden ones.
+func (w *TermWindow) handleColumnMenu(_ Event) {
+	columnar, ok := w.mainView.(Columnar)
+	if !ok || len(columnar.Columns()) == 0 {
+		return
+	}
+
+	visible := columnar.VisibleColumns()
+	if visible == nil {
+		visible = columnar.Columns()
+	}
+	w.columnOrder = append([]string(nil), visible...)
+	w.columnShown = make([]bool, len(visible))
+	for i := range w.columnShown {
+		w.columnShown[i] = true
+	}
+	for _, col := range columnar.Columns() {
+		if !contains(visible, col) {
+			w.columnOrder = append(w.columnOrder, col)
+			w.columnShown = append(w.columnShown, false)
+		}
+	}
+
+	w.view = columns
+	w.columnPanel.SelectedRow = 0
+	w.keybindings = w.columnKeybindings()
+	w.updateColumnPanel()
+}
+
+// handleColumnPanelScroll is called in columns state of the gui
+// to scroll the column picker.
+func (w *TermWindow) handleColumnPanelScroll(event Event) {
+	switch event.Payload.(string) {
+	case KeyScrollUp:
+		w.columnPanel.ScrollUp()
+	case KeyScrollDown:
+		w.columnPanel.ScrollDown()
+	}
+}
+
+// handleToggleColumn shows or hides the selected column.
+// The last displayed column can't be hidden.
+func (w *TermWindow) handleToggleColumn(_ Event) {
+	i := w.columnPanel.SelectedRow
+	if w.columnShown[i] && len(w.shownColumns()) == 1 {
+		return
+	}
+	w.columnShown[i] = !w.columnShown[i]
+	w.applyColumns()
+}
+
+// handleMoveColumn moves the selected column up or down in the order.
+func (w *TermWindow) handleMoveColumn(event Event) {
+	i := w.columnPanel.SelectedRow
+	j := i - 1
+	if event.Payload.(string) == KeyMoveDown {
+		j = i + 1
+	}
+	if j < 0 || j >= len(w.columnOrder) {
+		return
+	}
+	w.columnOrder[i], w.columnOrder[j] = w.columnOrder[j], w.columnOrder[i]
+	w.columnShown[i], w.columnShown[j] = w.columnShown[j], w.columnShown[i]
+	w.columnPanel.SelectedRow = j
+	w.applyColumns()
+}
+
+// handleResizeColumn makes the selected column narrower or wider.
+func (w *TermWindow) handleResizeColumn(event Event) {
+	columnar, ok := w.mainView.(Columnar)
+	if !ok || !w.columnShown[w.columnPanel.SelectedRow] {
+		return
+	}
+	delta := 1
+	if event.Payload.(string) == KeyTabLeft {
+		delta = -1
+	}
+	columnar.ResizeColumn(w.columnOrder[w.columnPanel.SelectedRow], delta)
+	w.updateColumnPanel()
+}
+
+// applyColumns sets the displayed columns of the main view
+// to the columns shown in the column picker.
+func (w *TermWindow) applyColumns() {
+	if columnar, ok := w.mainView.(Columnar); ok {
+		columnar.SetColumns(w.shownColumns())
+	}
+	w.updateColumnPanel()
+}
+
+// shownColumns returns the columns shown in the column picker.
+func (w *TermWindow) shownColumns() []string {
+	var shown []string
+	for i, col := range w.columnOrder {
+		if w.columnShown[i] {
+			shown = append(shown, col)
+		}
+	}
+	return shown
+}
+
+// updateColumnPanel updates the rows of the column picker.
+func (w *TermWindow) updateColumnPanel() {
+	columnar, ok := w.mainView.(Columnar)
+	if !ok {
+		return
+	}
+	rows := make([]string, len(w.columnOrder))
+	for i, col := range w.columnOrder {
+		mark, width := " ", ""
+		if w.columnShown[i] {
+			mark = "x"
+			width = fmt.Sprint(columnar.ColumnWidth(col))
+		}
+		rows[i] = fmt.Sprintf("[%s] %-24s %4s", mark, col, width)
+	}
+	w.columnPanel.Rows = rows
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -31,4 +31,11 @@ type (
 		CurrTab int
 		CurrRow int
 	}
+
+	// Click is the payload for event used on mouse click.
+	// X and Y are the coordinates of the click in the terminal.
+	Click struct {
+		X, Y   int
+		Double bool
+	}
 )
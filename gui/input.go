@@ -22,6 +22,8 @@ const (
 	KeyTabRight   = "<Right>"
 	KeyScrollDown = "<Down>"
 	KeyScrollUp   = "<Up>"
+	KeyPanLeft    = "<"
+	KeyPanRight   = ">"
 	KeyQuit       = "q"
 	KeyFilter     = "/"
 	KeyCancel     = "<Escape>"
@@ -38,6 +40,26 @@ const (
 	KeyF10        = "<F10>"
 	KeyF11        = "<F11>"
 	KeyF12        = "<F12>"
+	KeyColumns    = "c"
+	KeyLayout     = "l"
+	KeyHighlight  = "h"
+	KeyGroup      = "g"
+	KeyUnits      = "u"
+	KeyRates      = "b"
+	KeyChart      = "t"
+	KeyMark       = "m"
+	KeyMarks      = "M"
+	KeyDelta      = "d"
+	KeyIdle       = "i"
+	KeyZoomIn     = "+"
+	KeyZoomOut    = "-"
+	KeyFilterOff  = "f"
+	KeySearch     = "s"
+	KeyNextMatch  = "n"
+	KeyPrevMatch  = "N"
+	KeySpace      = "<Space>"
+	KeyMoveUp     = "["
+	KeyMoveDown   = "]"
 	KeyInsert     = "<Insert>"
 	KeyDelete     = "<Delete>"
 	KeyHome       = "<Home>"
@@ -84,19 +106,77 @@ type Binding struct {
 	callback func(Event)
 }
 
-// DefaultKeybindings are keybindings for the default view.
+// DefaultKeybindings are keybindings for the default view,
+// followed by the custom keybindings.
 func (w *TermWindow) defaultKeybindings() []*Binding {
-	return []*Binding{
+	bindings := []*Binding{
 		{key: KeyQuit, callback: w.handleExit},
 		{key: KeyCtrlSpace, callback: w.handleSortMenu},
 		{key: KeyScrollDown, callback: w.handleScroll},
 		{key: KeyScrollUp, callback: w.handleScroll},
 		{key: KeyPgup, callback: w.handleScroll},
 		{key: KeyPgdn, callback: w.handleScroll},
+		{key: KeyPanLeft, callback: w.handleScroll},
+		{key: KeyPanRight, callback: w.handleScroll},
 		{key: KeyTabLeft, callback: w.handleTabSwitch},
 		{key: KeyTabRight, callback: w.handleTabSwitch},
 		{key: KeyFilter, callback: w.handleFilterMenu},
+		{key: KeyFilterOff, callback: w.handleToggleFilter},
+		{key: KeySearch, callback: w.handleSearchMenu},
+		{key: KeyNextMatch, callback: w.handleNextMatch},
+		{key: KeyPrevMatch, callback: w.handleNextMatch},
 		{key: KeyCtrlC, callback: w.handleClear},
+		{key: KeyEnter, callback: w.handleDetails},
+		{key: KeySpace, callback: w.handleToggleGroup},
+		{key: KeyColumns, callback: w.handleColumnMenu},
+	}
+	return append(bindings, w.customKeybindings...)
+}
+
+// ColumnKeybindings are keybindings for the column picker view.
+func (w *TermWindow) columnKeybindings() []*Binding {
+	return []*Binding{
+		{key: KeyCancel, callback: w.handleDefaultMenu},
+		{key: KeyColumns, callback: w.handleDefaultMenu},
+		{key: KeyScrollDown, callback: w.handleColumnPanelScroll},
+		{key: KeyScrollUp, callback: w.handleColumnPanelScroll},
+		{key: KeySpace, callback: w.handleToggleColumn},
+		{key: KeyEnter, callback: w.handleToggleColumn},
+		{key: KeyMoveUp, callback: w.handleMoveColumn},
+		{key: KeyMoveDown, callback: w.handleMoveColumn},
+		{key: KeyTabLeft, callback: w.handleResizeColumn},
+		{key: KeyTabRight, callback: w.handleResizeColumn},
+	}
+}
+
+// DetailsKeybindings are keybindings for the details view.
+func (w *TermWindow) detailsKeybindings() []*Binding {
+	return []*Binding{
+		{key: KeyQuit, callback: w.handleExit},
+		{key: KeyCancel, callback: w.handleDefaultMenu},
+		{key: KeyEnter, callback: w.handleDefaultMenu},
+	}
+}
+
+// ChartKeybindings are keybindings for the chart view.
+func (w *TermWindow) chartKeybindings() []*Binding {
+	return []*Binding{
+		{key: KeyQuit, callback: w.handleExit},
+		{key: KeyCancel, callback: w.handleDefaultMenu},
+		{key: KeyEnter, callback: w.handleDefaultMenu},
+		{key: KeyZoomIn, callback: w.handleZoom},
+		{key: KeyZoomOut, callback: w.handleZoom},
+	}
+}
+
+// MarkKeybindings are keybindings for the mark list view.
+func (w *TermWindow) markKeybindings() []*Binding {
+	return []*Binding{
+		{key: KeyCancel, callback: w.handleDefaultMenu},
+		{key: KeyMarks, callback: w.handleDefaultMenu},
+		{key: KeyScrollDown, callback: w.handleMarkPanelScroll},
+		{key: KeyScrollUp, callback: w.handleMarkPanelScroll},
+		{key: KeyEnter, callback: w.handlePickMark},
 	}
 }
 
@@ -115,6 +195,21 @@ func (w *TermWindow) filterKeybindings() []*Binding {
 	}
 }
 
+// SearchKeybindings are keybindings for the search view.
+func (w *TermWindow) searchKeybindings() []*Binding {
+	return []*Binding{
+		{key: KeyCancel, callback: w.handleDefaultMenu},
+		{key: KeyScrollUp, callback: w.handleDefaultMenu},
+		{key: KeyScrollDown, callback: w.handleDefaultMenu},
+		{key: KeyTabLeft, callback: w.handleDefaultMenu},
+		{key: KeyTabRight, callback: w.handleDefaultMenu},
+		{key: KeyEnter, callback: w.handleSearch},
+		{key: KeyTab, callback: w.handleDefaultMenu},
+		{key: KeyDeleteChar, callback: w.handleReduceSearch},
+		{key: Any, callback: w.handleAppendToSearch},
+	}
+}
+
 // SortKeybindings are keybindings for the sort view.
 func (w *TermWindow) sortKeybindings() []*Binding {
 	return []*Binding{
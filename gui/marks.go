@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gui
+
+// ShowMarks opens the list of the marks with the selected one
+// highlighted. The function is called with the index of the mark
+// picked from the list, after the list is closed.
+func (w *TermWindow) ShowMarks(names []string, selected int, f func(mark int)) {
+	if len(names) == 0 {
+		return
+	}
+	if selected < 0 || selected >= len(names) {
+		selected = len(names) - 1
+	}
+	w.view = marks
+	w.markPanel.Rows = names
+	w.markPanel.SelectedRow = selected
+	w.onMark = f
+	w.keybindings = w.markKeybindings()
+}
+
+// SetMode displays the description of a mode of the application
+// in the status, e.g. the values relative to a mark. An empty
+// text removes it.
+func (w *TermWindow) SetMode(text string) {
+	w.mode = text
+}
+
+// handleMarkPanelScroll is called in marks state of the gui
+// to scroll the mark list.
+func (w *TermWindow) handleMarkPanelScroll(event Event) {
+	switch event.Payload.(string) {
+	case KeyScrollUp:
+		w.markPanel.ScrollUp()
+	case KeyScrollDown:
+		w.markPanel.ScrollDown()
+	}
+}
+
+// handlePickMark closes the mark list and notifies
+// the listener of the picked mark.
+func (w *TermWindow) handlePickMark(event Event) {
+	f, mark := w.onMark, w.markPanel.SelectedRow
+	w.handleDefaultMenu(event)
+	if f != nil {
+		f(mark)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gui
+
+import (
+	"time"
+
+	tui "github.com/gizak/termui/v3"
+)
+
+// gui supported mouse buttons.
+const (
+	MouseLeft      = "<MouseLeft>"
+	MouseWheelUp   = "<MouseWheelUp>"
+	MouseWheelDown = "<MouseWheelDown>"
+)
+
+// doubleClickInterval is the maximum time between
+// two clicks at the same row to be a double click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// processMouse is called when a mouse event occurs.
+func (w *TermWindow) processMouse(button string, mouse tui.Mouse) {
+	// termbox repeats the event while the button is held.
+	if mouse.Drag {
+		return
+	}
+
+	switch w.view {
+	case def:
+		switch button {
+		case MouseWheelUp:
+			w.handleScroll(Event{Payload: KeyScrollUp})
+		case MouseWheelDown:
+			w.handleScroll(Event{Payload: KeyScrollDown})
+		case MouseLeft:
+			w.handleClick(mouse.X, mouse.Y)
+		}
+	case sort:
+		switch button {
+		case MouseWheelUp:
+			w.handleSortPanelScroll(Event{Payload: KeyScrollUp})
+		case MouseWheelDown:
+			w.handleSortPanelScroll(Event{Payload: KeyScrollDown})
+		}
+	case columns:
+		switch button {
+		case MouseWheelUp:
+			w.handleColumnPanelScroll(Event{Payload: KeyScrollUp})
+		case MouseWheelDown:
+			w.handleColumnPanelScroll(Event{Payload: KeyScrollDown})
+		}
+	case marks:
+		switch button {
+		case MouseWheelUp:
+			w.handleMarkPanelScroll(Event{Payload: KeyScrollUp})
+		case MouseWheelDown:
+			w.handleMarkPanelScroll(Event{Payload: KeyScrollDown})
+		}
+	case details:
+		if button == MouseLeft {
+			w.handleDefaultMenu(Event{Payload: button})
+		}
+	case chart:
+		switch button {
+		case MouseWheelUp:
+			w.handleZoom(Event{Payload: KeyZoomIn})
+		case MouseWheelDown:
+			w.handleZoom(Event{Payload: KeyZoomOut})
+		}
+	}
+}
+
+// handleClick is called when the left mouse
+// button is clicked in the default view.
+func (w *TermWindow) handleClick(x, y int) {
+	click := Click{X: x, Y: y}
+	now := time.Now()
+	click.Double = now.Sub(w.lastClickTime) < doubleClickInterval &&
+		w.lastClick.X == x && w.lastClick.Y == y
+	w.lastClick = click
+	w.lastClickTime = now
+	if click.Double {
+		// a third click starts a new double click.
+		w.lastClickTime = time.Time{}
+	}
+
+	if tab := w.tabAt(x, y); tab >= 0 {
+		if tab != w.currentTab() {
+			w.SetActiveTab(tab)
+		}
+		return
+	}
+
+	clickable, ok := w.mainView.(Clickable)
+	if !ok {
+		return
+	}
+	if item := clickable.OnClick(Event{Payload: click}); item >= 0 {
+		w.sortBy(item)
+		return
+	}
+	if click.Double {
+		w.handleDetails(Event{Payload: click})
+	}
+}
+
+// tabAt returns the index of the tab at x, y, or -1 if there is no tab.
+// The layout matches the draw method of the tui TabPane.
+func (w *TermWindow) tabAt(x, y int) int {
+	inner := w.tabPane.Inner
+	if y != inner.Min.Y || x < inner.Min.X || x >= inner.Max.X {
+		return -1
+	}
+	tabX := inner.Min.X
+	for i, name := range w.tabPane.TabNames {
+		if x >= tabX && x < tabX+len(name) {
+			return i
+		}
+		// name is followed by a space, separator and a space.
+		tabX += len(name) + 3
+	}
+	return -1
+}
@@ -42,6 +42,14 @@ const (
 	SortPanelTopY    = 7
 	SortPanelBottomX = 23
 
+	ColumnPanelTopX    = 0
+	ColumnPanelTopY    = 7
+	ColumnPanelBottomX = 40
+
+	MarkPanelTopX    = 0
+	MarkPanelTopY    = 7
+	MarkPanelBottomX = 40
+
 	NotificationBottomX = 75
 	NotificationBottomY = 75
 )
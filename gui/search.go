@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// handleSearchMenu changes the main view to the search menu,
+// if the view supports it.
+func (w *TermWindow) handleSearchMenu(_ Event) {
+	if _, ok := w.mainView.(Searchable); !ok {
+		return
+	}
+	w.view = search
+	w.keybindings = w.searchKeybindings()
+}
+
+// handleSearch changes the gui state to the default
+// state and selects the next matching entry.
+func (w *TermWindow) handleSearch(event Event) {
+	w.handleFilter(event)
+	if searchable, ok := w.mainView.(Searchable); ok {
+		searchable.NextMatch(true)
+	}
+}
+
+// handleNextMatch selects the next or the previous
+// entry matching the search, depending on the key.
+func (w *TermWindow) handleNextMatch(event Event) {
+	searchable, ok := w.mainView.(Searchable)
+	if !ok || w.search.Text == "" {
+		return
+	}
+	searchable.NextMatch(event.Payload.(string) == KeyNextMatch)
+}
+
+// handleReduceSearch is called when the users shortens the search.
+func (w *TermWindow) handleReduceSearch(_ Event) {
+	if len(w.search.Text) != 0 {
+		w.search.Text = w.search.Text[:len(w.search.Text)-1]
+	}
+}
+
+// handleAppendToSearch is called when the users appends to the search.
+func (w *TermWindow) handleAppendToSearch(event Event) {
+	payload := event.Payload.(string)
+	if payload == KeySpace {
+		payload = " "
+	}
+	// the other special keys and the mouse events are not text.
+	if len(payload) > 1 && strings.HasPrefix(payload, "<") {
+		return
+	}
+	w.search.Text = w.search.Text + payload
+}
+
+// matchesText returns the number of matches of the search.
+func (w *TermWindow) matchesText() string {
+	searchable, ok := w.mainView.(Searchable)
+	if !ok {
+		return ""
+	}
+	current, total := searchable.Matches()
+	switch {
+	case total == 0:
+		return "no matches"
+	case current == 0:
+		return fmt.Sprintf("%d matches", total)
+	default:
+		return fmt.Sprintf("%d/%d matches", current, total)
+	}
+}
+
+// statusText returns the description of the active filter and
+// search, or an empty string if there are none.
+func (w *TermWindow) statusText() string {
+	var status []string
+	if w.filter.Text != "" {
+		if w.filterOff[w.currentTab()] {
+			status = append(status, fmt.Sprintf("Filter (off, %v to turn on): %s", KeyFilterOff, w.filter.Text))
+		} else {
+			status = append(status, fmt.Sprintf("Filter (%v to turn off): %s", KeyFilterOff, w.filter.Text))
+		}
+	}
+	if _, ok := w.mainView.(Searchable); ok && w.search.Text != "" {
+		status = append(status, fmt.Sprintf("Search (%v/%v): %s, %s",
+			KeyNextMatch, KeyPrevMatch, w.search.Text, w.matchesText()))
+	}
+	if w.mode != "" {
+		status = append(status, w.mode)
+	}
+	return strings.Join(status, " | ")
+}
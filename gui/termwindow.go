@@ -20,21 +20,32 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/PantheonTechnologies/vpptop/gui/xtui"
 	tui "github.com/gizak/termui/v3"
 	"github.com/gizak/termui/v3/widgets"
 )
 
 // viewType represents the current state of the gui.
-// As of now it supports only 3 views.
+// As of now it supports only 8 views.
 // 1 - default (where only the tabPane Version, and tabViews are rendered).
 // 2 - sort (where on top of the default widgets a sort panel is rendered).
 // 3 - filter (where on top of the default widgets a filter is rendered).
+// 4 - details (where the details of the selected entry are rendered instead of the tabView).
+// 5 - columns (where on top of the default widgets a column picker is rendered).
+// 6 - search (where on top of the default widgets a search is rendered).
+// 7 - chart (where a chart of the selected entry is rendered instead of the tabView).
+// 8 - marks (where on top of the default widgets a list of the marks is rendered).
 type viewType uint
 
 const (
 	sort viewType = iota
 	filter
 	def
+	details
+	columns
+	search
+	chart
+	marks
 )
 
 // TermWindow represents terminal gui that can handle up to multiple tabs
@@ -50,23 +61,61 @@ type TermWindow struct {
 	// gui components.
 	mainView TabView
 	views    []TabView
+	// tabNames are the names of the tabs without their badges.
+	tabNames []string
 
 	exitView     TabView
 	sortPanel    *widgets.List
 	tabPane      *widgets.TabPane
 	filter       *widgets.Paragraph
 	filterExit   *widgets.Paragraph
+	filterError  *widgets.Paragraph
+	search       *widgets.Paragraph
+	searchExit   *widgets.Paragraph
+	matches      *widgets.Paragraph
+	status       *widgets.Paragraph
 	version      *widgets.Paragraph
 	notification *widgets.Paragraph
+	details      *widgets.Paragraph
+	chart        *xtui.Chart
+	columnPanel  *widgets.List
+	markPanel    *widgets.List
 
 	// keybidings
 	keybindings []*Binding
+	// custom keybindings added to the default view.
+	customKeybindings []*Binding
+
+	// terminal dimensions.
+	width, height int
+
+	// filters are the filters of the tabs, the filter of the
+	// active tab is kept in the filter widget until a tab switch.
+	filters []string
+	// filterOff marks the tabs with the filter toggled off.
+	filterOff []bool
+
+	// columns of the main view in the column picker, in the
+	// order they are listed, and whether they are displayed.
+	columnOrder []string
+	columnShown []bool
+
+	// onMark is called with the mark picked from the mark list.
+	onMark func(mark int)
+	// mode describes a mode of the application displayed
+	// in the status, e.g. the values relative to a mark.
+	mode string
+
+	// last left mouse click, used to detect double clicks.
+	lastClick     Click
+	lastClickTime time.Time
 
 	timerDuration     time.Duration
 	notificationTimer *time.Timer
 
 	// channels & callbacks.
-	stop chan struct{}
+	stop    chan struct{}
+	actions chan func()
 
 	windowEvents <-chan tui.Event
 	refresh      <-chan time.Time
@@ -86,6 +135,7 @@ func NewTermWindow(refreshInterval time.Duration, views []TabView, viewNames []s
 	window.refresh = time.NewTicker(refreshInterval).C
 	window.windowEvents = tui.PollEvents()
 	window.stop = make(chan struct{})
+	window.actions = make(chan func())
 
 	window.timerDuration = 1 * time.Second
 	window.notificationTimer = time.NewTimer(window.timerDuration)
@@ -96,6 +146,8 @@ func NewTermWindow(refreshInterval time.Duration, views []TabView, viewNames []s
 	window.clearTabs = clearTabs
 
 	window.views = views
+	window.filters = make([]string, len(views))
+	window.filterOff = make([]bool, len(views))
 	if len(window.views) != 0 {
 		window.mainView = window.views[0]
 	}
@@ -104,10 +156,9 @@ func NewTermWindow(refreshInterval time.Duration, views []TabView, viewNames []s
 
 	window.sortPanel = widgets.NewList()
 	window.sortPanel.Border = true
-	window.sortPanel.TextStyle = tui.NewStyle(textStyle, tui.ColorBlue, tui.ModifierBold)
-	window.sortPanel.SelectedRowStyle = tui.NewStyle(tui.ColorYellow, tui.ColorBlue, tui.ModifierBold)
 	window.sortPanel.Title = "Sort by"
 
+	window.tabNames = viewNames
 	window.tabPane = widgets.NewTabPane(viewNames...)
 	window.tabPane.SetRect(TabPaneTopX, TabPaneTopY, TabPaneBottomX, TabPaneBottomY)
 	window.tabPane.Border = false
@@ -116,14 +167,42 @@ func NewTermWindow(refreshInterval time.Duration, views []TabView, viewNames []s
 	window.filter.SetRect(FilterTopX, FilterTopY, FilterBottomX, FilterBottomY)
 	window.filter.Border = false
 	window.filter.WrapText = false
-	window.filter.TextStyle = tui.NewStyle(textStyle, filterBackground, tui.ModifierBold)
 
 	window.filterExit = widgets.NewParagraph()
 	window.filterExit.SetRect(FilterExitTopX, FilterExitTopY, FilterExitBottomX, FilterExitBottomY)
 	window.filterExit.Border = false
 	window.filterExit.WrapText = false
 	window.filterExit.Text = fmt.Sprintf("Exit:%v filter:", KeyCancel)
-	window.filterExit.TextStyle = tui.NewStyle(textStyle, filterBackground, tui.ModifierBold)
+
+	// the error is displayed below the filter.
+	window.filterError = widgets.NewParagraph()
+	window.filterError.SetRect(FilterTopX, FilterTopY+1, FilterBottomX, FilterBottomY+1)
+	window.filterError.Border = false
+	window.filterError.WrapText = false
+
+	window.search = widgets.NewParagraph()
+	window.search.SetRect(FilterTopX, FilterTopY, FilterBottomX, FilterBottomY)
+	window.search.Border = false
+	window.search.WrapText = false
+
+	window.searchExit = widgets.NewParagraph()
+	window.searchExit.SetRect(FilterExitTopX, FilterExitTopY, FilterExitBottomX, FilterExitBottomY)
+	window.searchExit.Border = false
+	window.searchExit.WrapText = false
+	window.searchExit.Text = fmt.Sprintf("Exit:%v search:", KeyCancel)
+
+	// the number of matches is displayed below the search.
+	window.matches = widgets.NewParagraph()
+	window.matches.SetRect(FilterTopX, FilterTopY+1, FilterBottomX, FilterBottomY+1)
+	window.matches.Border = false
+	window.matches.WrapText = false
+
+	// the active filter and search are displayed in place
+	// of the filter when they aren't being edited.
+	window.status = widgets.NewParagraph()
+	window.status.SetRect(FilterExitTopX, FilterExitTopY, FilterBottomX, FilterBottomY)
+	window.status.Border = false
+	window.status.WrapText = false
 
 	window.version = widgets.NewParagraph()
 	window.version.SetRect(VersionTopX, VersionTopY, VersionBottomX, VersionBottomY)
@@ -133,12 +212,85 @@ func NewTermWindow(refreshInterval time.Duration, views []TabView, viewNames []s
 	window.notification = widgets.NewParagraph()
 	window.notification.Border = false
 	window.notification.WrapText = false
-	window.notification.TextStyle = tui.NewStyle(textStyle, tui.ColorBlue, tui.ModifierBold)
 
-	widgets.NewTabPane()
+	window.details = widgets.NewParagraph()
+	window.details.Title = fmt.Sprintf("Details (%v to close)", KeyCancel)
+	window.details.WrapText = false
+
+	window.chart = xtui.NewChart()
+
+	window.columnPanel = widgets.NewList()
+	window.columnPanel.Border = true
+	window.columnPanel.Title = "Columns"
+
+	window.markPanel = widgets.NewList()
+	window.markPanel.Border = true
+	window.markPanel.Title = "Marks"
+
+	window.applyTheme()
 	return window
 }
 
+// applyTheme sets the colors of the window widgets from the current theme.
+func (w *TermWindow) applyTheme() {
+	w.sortPanel.TextStyle = theme.SortPanel
+	w.sortPanel.SelectedRowStyle = theme.SortPanelSelected
+	w.sortPanel.BorderStyle = tui.NewStyle(theme.Text)
+	w.sortPanel.TitleStyle = tui.NewStyle(theme.Text)
+	w.columnPanel.TextStyle = theme.SortPanel
+	w.columnPanel.SelectedRowStyle = theme.SortPanelSelected
+	w.columnPanel.BorderStyle = tui.NewStyle(theme.Text)
+	w.columnPanel.TitleStyle = tui.NewStyle(theme.Text)
+	w.markPanel.TextStyle = theme.SortPanel
+	w.markPanel.SelectedRowStyle = theme.SortPanelSelected
+	w.markPanel.BorderStyle = tui.NewStyle(theme.Text)
+	w.markPanel.TitleStyle = tui.NewStyle(theme.Text)
+	w.tabPane.ActiveTabStyle = theme.TabActive
+	w.tabPane.InactiveTabStyle = theme.TabInactive
+	w.filter.TextStyle = theme.Filter
+	w.filterExit.TextStyle = theme.Filter
+	w.filterError.TextStyle = theme.SeverityCritical
+	w.search.TextStyle = theme.Filter
+	w.searchExit.TextStyle = theme.Filter
+	w.matches.TextStyle = theme.Filter
+	w.status.TextStyle = theme.Filter
+	w.version.TextStyle = tui.NewStyle(theme.Text)
+	w.notification.TextStyle = theme.Notification
+	w.details.TextStyle = tui.NewStyle(theme.Text)
+	w.details.BorderStyle = tui.NewStyle(theme.Text)
+	w.details.TitleStyle = tui.NewStyle(theme.Text)
+	w.chart.BorderStyle = tui.NewStyle(theme.Text)
+	w.chart.TitleStyle = tui.NewStyle(theme.Text)
+	w.chart.AxesStyle = tui.NewStyle(theme.Text)
+	w.chart.Colors = []tui.Color{theme.Changed.Fg, theme.OverThreshold.Fg, theme.SeverityWarning.Fg, theme.Increased.Fg}
+}
+
+// SetTheme changes the theme of the gui, including all views
+// that implement the Themed interface.
+func (w *TermWindow) SetTheme(t *Theme) {
+	SetTheme(t)
+	w.applyTheme()
+	for _, view := range append(w.views, w.exitView) {
+		if themed, ok := view.(Themed); ok {
+			themed.SetTheme(t)
+		}
+	}
+}
+
+// AddKeybinding registers a function that will be called when the key
+// is pressed in the default view. The Event payload is the current tab.
+func (w *TermWindow) AddKeybinding(key string, f func(Event)) {
+	w.customKeybindings = append(w.customKeybindings, &Binding{
+		key: key,
+		callback: func(Event) {
+			f(Event{Payload: w.currentTab()})
+		},
+	})
+	if w.view == def {
+		w.keybindings = w.defaultKeybindings()
+	}
+}
+
 // AddOnExitCallback registers a single function that will be called
 // on gui exit.
 func (w *TermWindow) AddOnExitCallback(f func(Event)) {
@@ -169,6 +321,78 @@ func (w *TermWindow) SetVersion(s string) {
 	w.version.Text = s
 }
 
+// SetTabBadge displays the badge next to the name of the tab at index,
+// e.g. the number of alerts. An empty badge removes it. The tab pane
+// is widened to fit the badges, moving the version to the right.
+func (w *TermWindow) SetTabBadge(tab int, badge string) {
+	if tab < 0 || tab >= len(w.tabNames) {
+		return
+	}
+	w.tabPane.TabNames[tab] = w.tabNames[tab]
+	if badge != "" {
+		w.tabPane.TabNames[tab] += " " + badge
+	}
+
+	// the names are separated by a space, separator and a space.
+	width := 2 + 3*(len(w.tabPane.TabNames)-1)
+	for _, name := range w.tabPane.TabNames {
+		width += len([]rune(name))
+	}
+	if width < TabPaneBottomX {
+		width = TabPaneBottomX
+	}
+	w.tabPane.SetRect(TabPaneTopX, TabPaneTopY, width, TabPaneBottomY)
+	w.version.SetRect(VersionTopX+width-TabPaneBottomX, VersionTopY, VersionBottomX+width-TabPaneBottomX, VersionBottomY)
+}
+
+// Notify displays the text in the notification area
+// until the duration elapses or another text is displayed.
+func (w *TermWindow) Notify(text string, d time.Duration) {
+	w.notificationTimer.Reset(d)
+	w.notification.Text = text
+}
+
+// SetFilter sets the filter that is applied each time the tab
+// is switched to. If the tab is active, the filter is applied immediately.
+func (w *TermWindow) SetFilter(tab int, filter string) {
+	w.filters[tab] = filter
+	if tab == w.currentTab() {
+		w.filter.Text = filter
+	}
+}
+
+// Filter returns the filter of the tab at index.
+func (w *TermWindow) Filter(tab int) string {
+	if tab == w.currentTab() {
+		return w.filter.Text
+	}
+	return w.filters[tab]
+}
+
+// ActiveTab returns the index of the active tab.
+func (w *TermWindow) ActiveTab() int {
+	return w.currentTab()
+}
+
+// SetActiveTab switches the gui to the tab at index.
+func (w *TermWindow) SetActiveTab(tab int) {
+	if tab < 0 || tab >= len(w.views) {
+		return
+	}
+	w.filters[w.currentTab()] = w.filter.Text
+	w.tabPane.ActiveTabIndex = tab
+	w.switchTab()
+}
+
+// Exec runs the function from the gui main loop. It should be used
+// when changing the state of the gui from a different go-routine.
+func (w *TermWindow) Exec(f func()) {
+	select {
+	case w.actions <- f:
+	case <-w.stop:
+	}
+}
+
 // handleExit changes the main view to the exit screen, and notifies
 // all listeners for the onExit event.
 func (w *TermWindow) handleExit(event Event) {
@@ -182,20 +406,17 @@ func (w *TermWindow) handleExit(event Event) {
 // pushNotification resets the timer for the displayed
 // notification and updates the text.
 func (w *TermWindow) pushNotification(text string) {
-	isPresent := func(tabs []int, currTab int) bool {
-		for _, tab := range tabs {
-			if tab == currTab {
-				return true
-			}
-		}
-		return false
-	}
+	w.Notify(text, w.timerDuration)
+}
 
-	currTab := w.currentTab()
-	if isPresent(w.clearTabs, currTab) {
-		w.notificationTimer.Reset(w.timerDuration)
-		w.notification.Text = text
+// isClearTab returns true if the tab can be cleared.
+func (w *TermWindow) isClearTab(tab int) bool {
+	for _, t := range w.clearTabs {
+		if t == tab {
+			return true
+		}
 	}
+	return false
 }
 
 // handleSortMenu changes the main view to the sort menu.
@@ -207,6 +428,7 @@ func (w *TermWindow) handleSortMenu(_ Event) {
 
 // handleFilterMenu change the main view to the filter menu.
 func (w *TermWindow) handleFilterMenu(_ Event) {
+	w.filterOff[w.currentTab()] = false
 	w.view = filter
 	w.keybindings = w.filterKeybindings()
 }
@@ -218,6 +440,17 @@ func (w *TermWindow) handleDefaultMenu(event Event) {
 		w.sortPanel.Rows = []string{""}
 	case filter:
 		w.filter.Text = ""
+	case details:
+		w.details.Text = ""
+	case chart:
+		w.chart.SetSeries(nil)
+	case columns:
+		w.columnPanel.Rows = []string{""}
+	case marks:
+		w.markPanel.Rows = []string{""}
+		w.onMark = nil
+	case search:
+		w.search.Text = ""
 	}
 	w.handleFilter(event)
 }
@@ -228,6 +461,30 @@ func (w *TermWindow) handleFilter(_ Event) {
 	w.keybindings = w.defaultKeybindings()
 }
 
+// handleDetails changes the main view to the details
+// of the selected entry, if the view supports it.
+func (w *TermWindow) handleDetails(_ Event) {
+	detailed, ok := w.mainView.(Detailed)
+	if !ok {
+		return
+	}
+	text := detailed.Details()
+	if text == "" {
+		return
+	}
+	w.view = details
+	w.details.Text = text
+	w.keybindings = w.detailsKeybindings()
+}
+
+// handleToggleGroup expands or collapses the group
+// of the selected entry, if the view supports it.
+func (w *TermWindow) handleToggleGroup(_ Event) {
+	if grouped, ok := w.mainView.(Grouped); ok {
+		grouped.ToggleGroup()
+	}
+}
+
 // handleScroll is called when a scroll event occurs.
 func (w *TermWindow) handleScroll(event Event) {
 	w.mainView.OnScrollEvent(event)
@@ -235,25 +492,34 @@ func (w *TermWindow) handleScroll(event Event) {
 
 // handlePreviousTab is called when a tab switch event occurs.
 func (w *TermWindow) handleTabSwitch(event Event) {
+	w.filters[w.currentTab()] = w.filter.Text
 	switch event.Payload.(string) {
 	case KeyTabLeft:
 		w.tabPane.FocusLeft()
 	case KeyTabRight:
 		w.tabPane.FocusRight()
 	}
-	if w.filter.Text != "" {
-		w.filter.Text = ""
-	}
+	w.switchTab()
+}
+
+// switchTab changes the main view to the active tab,
+// the filter of the previous tab must be already saved.
+func (w *TermWindow) switchTab() {
+	w.filter.Text = w.filters[w.tabPane.ActiveTabIndex]
 	w.mainView = w.views[w.tabPane.ActiveTabIndex]
-	w.onTabswitch(Event{
-		Payload: w.tabPane.ActiveTabIndex,
-	})
+	if w.onTabswitch != nil {
+		w.onTabswitch(Event{
+			Payload: w.tabPane.ActiveTabIndex,
+		})
+	}
 }
 
 // handleClear is called when an on clear event occurs.
 func (w *TermWindow) handleClear(_ Event) {
 	currTab := w.currentTab()
-	w.pushNotification(fmt.Sprintf("clearing tab: %s", w.tabPane.TabNames[currTab]))
+	if w.isClearTab(currTab) {
+		w.pushNotification(fmt.Sprintf("clearing tab: %s", w.tabNames[currTab]))
+	}
 	if w.onClear != nil {
 		w.onClear(Event{
 			Payload: currTab,
@@ -261,6 +527,21 @@ func (w *TermWindow) handleClear(_ Event) {
 	}
 }
 
+// handleToggleFilter turns the filter of the active tab
+// off or back on, without changing it.
+func (w *TermWindow) handleToggleFilter(_ Event) {
+	tab := w.currentTab()
+	if w.filter.Text == "" {
+		return
+	}
+	w.filterOff[tab] = !w.filterOff[tab]
+	if w.filterOff[tab] {
+		w.pushNotification("filter off")
+	} else {
+		w.pushNotification("filter on")
+	}
+}
+
 // handleReduceFilter is called when the users shortens the filter.
 func (w *TermWindow) handleReduceFilter(_ Event) {
 	if len(w.filter.Text) != 0 {
@@ -271,7 +552,7 @@ func (w *TermWindow) handleReduceFilter(_ Event) {
 // handleAppendToFilter is called when the users appends to the filter.
 func (w *TermWindow) handleAppendToFilter(event Event) {
 	payload := event.Payload.(string)
-	if payload == "<Space>" {
+	if payload == KeySpace {
 		payload = " "
 	}
 	w.filter.Text = w.filter.Text + payload
@@ -279,10 +560,16 @@ func (w *TermWindow) handleAppendToFilter(event Event) {
 
 // handleSort is called when an sort event occurs.
 func (w *TermWindow) handleSort(_ Event) {
+	w.sortBy(w.sortPanel.SelectedRow)
+}
+
+// sortBy notifies the listener for the onSort event
+// to sort the current tab by the item at index.
+func (w *TermWindow) sortBy(item int) {
 	if w.onSort != nil {
 		w.onSort(Event{
 			Payload: SortMetadata{
-				CurrRow: w.sortPanel.SelectedRow,
+				CurrRow: item,
 				CurrTab: w.currentTab(),
 			},
 		})
@@ -317,7 +604,7 @@ func (w *TermWindow) processInput(key string) {
 		return false
 	}
 
-	if w.view == filter && !isPresent(w.keybindings, key) {
+	if (w.view == filter || w.view == search) && !isPresent(w.keybindings, key) {
 		w.keybindings[len(w.keybindings)-1].callback(Event{
 			Payload: key,
 		})
@@ -341,17 +628,53 @@ func (w *TermWindow) render() {
 		w.notification,
 	}
 
-	if w.mainView != nil {
+	if w.mainView != nil && w.view == details {
+		widgts = append(widgts, w.details)
+	} else if w.mainView != nil && w.view == chart {
+		widgts = append(widgts, w.chart)
+	} else if w.mainView != nil {
+		filterText := w.filter.Text
+		if w.filterOff[w.currentTab()] && w.view != filter {
+			filterText = ""
+		}
 		w.mainView.Filter(Event{
-			Payload: w.filter.Text,
+			Payload: filterText,
 		})
+		if searchable, ok := w.mainView.(Searchable); ok {
+			searchable.Search(Event{
+				Payload: w.search.Text,
+			})
+		}
 		widgts = append(widgts, w.mainView.Widgets()...)
 
+		if w.view != filter && w.view != search {
+			if status := w.statusText(); status != "" {
+				w.status.Text = status
+				widgts = append(widgts, w.status)
+			}
+		}
+
 		switch w.view {
 		case sort:
 			widgts = append(widgts, w.sortPanel)
 		case filter:
 			widgts = append(widgts, w.filter, w.filterExit)
+			if validator, ok := w.mainView.(FilterValidator); ok {
+				if err := validator.FilterError(); err != nil {
+					w.filterError.Text = err.Error()
+					widgts = append(widgts, w.filterError)
+				}
+			}
+		case columns:
+			widgts = append(widgts, w.columnPanel)
+		case marks:
+			widgts = append(widgts, w.markPanel)
+		case search:
+			widgts = append(widgts, w.search, w.searchExit)
+			if w.search.Text != "" {
+				w.matches.Text = w.matchesText()
+				widgts = append(widgts, w.matches)
+			}
 		}
 	}
 	tui.Clear()
@@ -371,6 +694,18 @@ func (w *TermWindow) currentTab() int {
 	return w.tabPane.ActiveTabIndex
 }
 
+// SetView replaces the view of the tab at index.
+// if out of bounds panics.
+func (w *TermWindow) SetView(i int, view TabView) {
+	if w.mainView == w.views[i] {
+		w.mainView = view
+	}
+	w.views[i] = view
+	if w.width != 0 || w.height != 0 {
+		view.Resize(w.width, w.height)
+	}
+}
+
 // ViewAtTab returns the tableView at index.
 // if out of bounds panics.
 func (w *TermWindow) ViewAtTab(i int) TabView {
@@ -390,10 +725,14 @@ func (w *TermWindow) Start() {
 			switch e.Type {
 			case tui.KeyboardEvent:
 				w.processInput(e.ID)
+			case tui.MouseEvent:
+				w.processMouse(e.ID, e.Payload.(tui.Mouse))
 			case tui.ResizeEvent:
 				payload := e.Payload.(tui.Resize)
 				w.resize(payload.Width, payload.Height)
 			}
+		case f := <-w.actions:
+			f()
 		case <-w.notificationTimer.C:
 			w.notification.Text = ""
 		case <-w.stop:
@@ -409,10 +748,15 @@ func (w *TermWindow) Destroy() {
 
 // resize resizes all widgets.
 func (w *TermWindow) resize(width, height int) {
+	w.width, w.height = width, height
 	for i := range w.views {
 		w.views[i].Resize(width, height)
 	}
 	w.exitView.Resize(width, height)
 	w.sortPanel.SetRect(SortPanelTopX, SortPanelTopY, SortPanelBottomX, height)
 	w.notification.SetRect(SortPanelTopX, height-2, NotificationBottomX, NotificationBottomY)
+	w.details.SetRect(SortPanelTopX, SortPanelTopY, width, height-2)
+	w.chart.SetRect(SortPanelTopX, SortPanelTopY, width, height-2)
+	w.columnPanel.SetRect(ColumnPanelTopX, ColumnPanelTopY, ColumnPanelBottomX, height)
+	w.markPanel.SetRect(MarkPanelTopX, MarkPanelTopY, MarkPanelBottomX, height)
 }
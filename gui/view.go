@@ -53,4 +53,71 @@ type (
 		// ItemsList returns the list of items to be sorted.
 		ItemsList() []string
 	}
-)
\ No newline at end of file
+
+	// Clickable is implemented by views which handle mouse clicks.
+	Clickable interface {
+		// OnClick is called when the left mouse button is clicked
+		// inside the view. The payload of the event is of type Click.
+		// If a column header was clicked, the index of the item from
+		// the ItemsList to sort by is returned, otherwise -1.
+		OnClick(Event) int
+	}
+
+	// Columnar is implemented by views with columns which can be
+	// hidden, reordered and resized by the user.
+	Columnar interface {
+		// Columns returns the names of all columns.
+		Columns() []string
+		// VisibleColumns returns the names of the displayed columns
+		// in the order they are displayed, nil if all are displayed.
+		VisibleColumns() []string
+		// SetColumns sets the displayed columns.
+		SetColumns([]string)
+		// ColumnWidth returns the displayed width of the column.
+		ColumnWidth(string) int
+		// ResizeColumn changes the width of the column by delta.
+		ResizeColumn(name string, delta int)
+	}
+
+	// Detailed is implemented by views which can show
+	// the details of the selected entry.
+	Detailed interface {
+		// Details returns the text describing the selected entry.
+		Details() string
+	}
+
+	// FilterValidator is implemented by views which
+	// can report an error in the filter.
+	FilterValidator interface {
+		// FilterError returns the error of the applied filter, if any.
+		FilterError() error
+	}
+
+	// Searchable is implemented by views which can
+	// highlight the entries matching a search.
+	Searchable interface {
+		// Search highlights the entries containing the text
+		// passed in the event, an empty text ends the search.
+		Search(Event)
+		// NextMatch selects the next matching entry,
+		// or the previous one if forward is false.
+		NextMatch(forward bool)
+		// Matches returns the number of the selected matching
+		// entry, 0 if it doesn't match, and the number of matches.
+		Matches() (current, total int)
+	}
+
+	// Grouped is implemented by views which display
+	// the entries in collapsible groups.
+	Grouped interface {
+		// ToggleGroup expands or collapses the group
+		// of the selected entry.
+		ToggleGroup()
+	}
+
+	// Themed is implemented by views which can change
+	// their colors when the theme of the gui changes.
+	Themed interface {
+		SetTheme(*Theme)
+	}
+)
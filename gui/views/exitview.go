@@ -50,11 +50,16 @@ func NewExitView() *exitView {
 	s.exitScreen.Border = false
 	s.exitScreen.WrapText = true
 	s.exitScreen.Text = "Closing.."
-	s.exitScreen.TextStyle = tui.NewStyle(tui.ColorWhite, tui.ColorBlack, tui.ModifierBold)
+	s.SetTheme(gui.CurrentTheme())
 
 	return s
 }
 
+// SetTheme changes the colors of the exitView.
+func (v *exitView) SetTheme(t *gui.Theme) {
+	v.exitScreen.TextStyle = tui.NewStyle(t.Text, tui.ColorClear, tui.ModifierBold)
+}
+
 // Drawables returns the widget to be drawn.
 func (v *exitView) Widgets() []tui.Drawable { return []tui.Drawable{v.exitScreen} }
 
@@ -17,6 +17,10 @@
 package views
 
 import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/PantheonTechnologies/vpptop/gui"
 	"github.com/PantheonTechnologies/vpptop/gui/xtui"
 	tui "github.com/gizak/termui/v3"
@@ -26,6 +30,11 @@ const (
 	// TableColResizedWithWindow represent that the column
 	// of the tableView should be resized with the terminal window.
 	TableColResizedWithWindow = -1
+
+	// tableColMinWidth is the minimal width of the columns resized
+	// with the window, the table is scrolled horizontally if the
+	// columns don't fit into the window.
+	tableColMinWidth = 10
 )
 
 // Table positions to match sort panel
@@ -39,23 +48,45 @@ const (
 	tableHeaderBottomY = gui.TabPaneBottomY + 4
 )
 
+// Entries are the rows of the entries of a table view and the values
+// they were formatted from, one per entry, aggregated by the grouping.
+type Entries struct {
+	Rows   xtui.TableRows
+	Values []interface{}
+}
+
 // TableView implements the view interface. It is a table build on xtui.Table.
 type TableView struct {
 	table  *xtui.Table
 	header *xtui.Table
 
 	itemsList []string
-	colWidth  []int
+	// colWidth are the widths of all columns, defaultWidth
+	// are the widths the tableView was created with.
+	colWidth     []int
+	defaultWidth []int
 
-	tw      int
-	resized []int
+	// columns are the indexes of the displayed columns,
+	// nil if all columns are displayed.
+	columns []int
+	// width of the terminal window.
+	width int
+	// scroll is the number of columns following the first
+	// one, which are scrolled out of the view.
+	scroll int
+	// headerRows are the header rows without the sort indicator.
+	headerRows xtui.TableRows
+	// sortItems maps the header columns to the
+	// indexes of the items to sort by, -1 if
+	// the column can't be sorted.
+	sortItems []int
 }
 
 // NewTableView returns a new instance of <*TableView>
-func NewTableView(itemsList []string, headerRows xtui.TableRows, filterCol, rowsPerEntry int, colWidths []int, light bool) *TableView {
+func NewTableView(itemsList []string, headerRows xtui.TableRows, filterCol, rowsPerEntry int, colWidths []int) *TableView {
 	v := &TableView{
-		table:     xtui.NewTable(light),
-		header:    xtui.NewTable(light),
+		table:     xtui.NewTable(),
+		header:    xtui.NewTable(),
 		itemsList: itemsList,
 	}
 	v.table.TextAlignment = tui.AlignLeft
@@ -67,40 +98,435 @@ func NewTableView(itemsList []string, headerRows xtui.TableRows, filterCol, rows
 	v.header.Border = false
 	v.header.RowSeparator = false
 	v.header.FillRow = true
-	v.header.Colors.SelectedRowFg = tui.ColorWhite
-	v.header.Colors.SelectedRowBg = tui.ColorRed
+	v.SetTheme(gui.CurrentTheme())
 
+	v.headerRows = headerRows
 	v.header.Rows = headerRows
 
 	v.table.InitFilter(filterCol, rowsPerEntry)
 
-	v.colWidth = colWidths
+	// by default the filter can compare the columns
+	// of the first row of an entry by their header name.
+	fields := make(map[string]xtui.Cell)
+	for i, col := range v.Columns() {
+		fields[col] = xtui.Cell{Row: 0, Column: i}
+	}
+	v.table.SetFilterFields(fields)
+
+	// without widths all columns are resized with the window.
+	if colWidths == nil {
+		colWidths = make([]int, len(v.Columns()))
+		for i := range colWidths {
+			colWidths[i] = TableColResizedWithWindow
+		}
+	}
+	v.defaultWidth = colWidths
+	v.colWidth = append([]int(nil), colWidths...)
 
-	for i, val := range v.colWidth {
-		if val == TableColResizedWithWindow {
-			v.resized = append(v.resized, i)
-		} else {
-			v.tw += v.colWidth[i]
+	// by default the columns are sorted by
+	// the items with the same name.
+	var sortItems []int
+	for _, col := range v.Columns() {
+		item := -1
+		for i := range itemsList {
+			if strings.EqualFold(col, itemsList[i]) {
+				item = i
+				break
+			}
 		}
+		sortItems = append(sortItems, item)
 	}
+	v.sortItems = sortItems
 	return v
 }
 
+// SetTheme changes the colors of the tableView.
+func (v *TableView) SetTheme(t *gui.Theme) {
+	v.table.SetStyles(tui.NewStyle(t.Text), t.SelectedRow, t.Match)
+	v.header.SetStyles(tui.NewStyle(t.Text), t.Header, t.Header)
+}
+
 // Resize resizes the tableView.
 func (v *TableView) Resize(w, h int) {
+	v.width = w
 	v.table.SetRect(tableTopX, tableTopY, w, h-1)
 	v.header.SetRect(tableHeaderTopX, tableHeaderTopY, w, tableHeaderBottomY)
+	v.resizeColumns()
+}
+
+// resizeColumns calculates the widths of the displayed columns, the columns
+// marked with TableColResizedWithWindow share the remaining width equally.
+func (v *TableView) resizeColumns() {
+	columns := v.columns
+	if columns == nil {
+		columns = make([]int, len(v.colWidth))
+		for i := range columns {
+			columns[i] = i
+		}
+	}
+
+	tw := 0
+	var resized []int
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		if v.colWidth[col] == TableColResizedWithWindow {
+			resized = append(resized, i)
+		} else {
+			widths[i] = v.colWidth[col]
+			tw += widths[i]
+		}
+	}
+	if len(resized) != 0 {
+		cw := (v.width - tw) / len(resized)
+		if cw < tableColMinWidth {
+			cw = tableColMinWidth
+		}
+		for _, i := range resized {
+			widths[i] = cw
+		}
+	}
+
+	v.table.Table.ColumnWidths = widths
+	v.header.Table.ColumnWidths = widths
+	v.scrollColumns(0)
+}
+
+// scrollColumns scrolls the columns following the first one by delta
+// columns to the right, at most until the last column is displayed.
+func (v *TableView) scrollColumns(delta int) {
+	widths := v.header.Table.ColumnWidths
+	maxScroll := 0
+	// each column is followed by a separator.
+	for tw := sum(widths) + len(widths) - 1; maxScroll < len(widths)-2 && tw > v.header.Inner.Dx(); maxScroll++ {
+		tw -= widths[1+maxScroll] + 1
+	}
+
+	v.scroll += delta
+	if v.scroll > maxScroll {
+		v.scroll = maxScroll
+	}
+	if v.scroll < 0 {
+		v.scroll = 0
+	}
+	v.table.SetScroll(v.scroll)
+	v.header.SetScroll(v.scroll)
+}
+
+// column returns the index of the column with the
+// header name, or -1 if there is no such column.
+func (v *TableView) column(name string) int {
+	for i, col := range v.Columns() {
+		if strings.EqualFold(name, col) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColumnWidth returns the width of the column as displayed,
+// or 0 if the column is unknown or hidden.
+func (v *TableView) ColumnWidth(name string) int {
+	col := v.column(name)
+	if col < 0 {
+		return 0
+	}
+	v.header.Lock()
+	defer v.header.Unlock()
+
+	widths := v.header.Table.ColumnWidths
+	if v.columns == nil {
+		if col < len(widths) {
+			return widths[col]
+		}
+		return 0
+	}
+	for i, c := range v.columns {
+		if c == col && i < len(widths) {
+			return widths[i]
+		}
+	}
+	return 0
+}
+
+// ResizeColumn changes the width of the column by delta. A column
+// resized with the window keeps its displayed width from now on.
+func (v *TableView) ResizeColumn(name string, delta int) {
+	col := v.column(name)
+	if col < 0 {
+		return
+	}
+	width := v.ColumnWidth(name) + delta
+	if width < 1 {
+		width = 1
+	}
+
+	v.table.Lock()
+	v.header.Lock()
+	defer v.table.Unlock()
+	defer v.header.Unlock()
+
+	v.colWidth[col] = width
+	v.resizeColumns()
+}
+
+// Widths returns the widths of the columns which differ from
+// the defaults, keyed by the header name.
+func (v *TableView) Widths() map[string]int {
+	widths := make(map[string]int)
+	for i, name := range v.Columns() {
+		if v.colWidth[i] != v.defaultWidth[i] {
+			widths[name] = v.colWidth[i]
+		}
+	}
+	if len(widths) == 0 {
+		return nil
+	}
+	return widths
+}
+
+// SetWidths sets the widths of the columns keyed by the header name,
+// the other columns are reset to their defaults. Names are case
+// insensitive and unknown names are ignored.
+func (v *TableView) SetWidths(widths map[string]int) {
+	v.table.Lock()
+	v.header.Lock()
+	defer v.table.Unlock()
+	defer v.header.Unlock()
+
+	copy(v.colWidth, v.defaultWidth)
+	for name, width := range widths {
+		if col := v.column(name); col >= 0 && width > 0 {
+			v.colWidth[col] = width
+		}
+	}
+	v.resizeColumns()
+}
+
+// Columns returns the header names of all columns of the table.
+func (v *TableView) Columns() []string {
+	if len(v.headerRows) == 0 {
+		return nil
+	}
+	return v.headerRows[0]
+}
+
+// SetSortIndicator marks the header columns sorted by the item
+// from the ItemsList with ▲ if ascending, ▼ if descending.
+// The marks are removed if the item is -1.
+func (v *TableView) SetSortIndicator(item int, asc bool) {
+	mark := " ▼"
+	if asc {
+		mark = " ▲"
+	}
+	rows := make(xtui.TableRows, len(v.headerRows))
+	for i, row := range v.headerRows {
+		rows[i] = append([]string(nil), row...)
+	}
+	if len(rows) != 0 && item >= 0 {
+		for col, sortItem := range v.sortItems {
+			if sortItem == item && col < len(rows[0]) {
+				rows[0][col] += mark
+			}
+		}
+	}
+
+	v.header.Lock()
+	defer v.header.Unlock()
+	v.header.Rows = rows
+}
+
+// VisibleColumns returns the header names of the displayed
+// columns, nil if all columns are displayed.
+func (v *TableView) VisibleColumns() []string {
+	if v.columns == nil {
+		return nil
+	}
+	names := make([]string, len(v.columns))
+	for i, col := range v.columns {
+		names[i] = v.Columns()[col]
+	}
+	return names
+}
+
+// Position returns the scroll position of the table.
+func (v *TableView) Position() (offset, selected int) {
+	v.table.Lock()
+	defer v.table.Unlock()
+	return v.table.Position()
+}
+
+// SetPosition sets the scroll position of the table.
+func (v *TableView) SetPosition(offset, selected int) {
+	v.table.Lock()
+	defer v.table.Unlock()
+	v.table.SetPosition(offset, selected)
+}
+
+// SetColumns sets the displayed columns by their header names, in the order
+// they should be displayed. Names are case insensitive and unknown names are
+// ignored. If no known name is passed all columns are displayed.
+func (v *TableView) SetColumns(names []string) {
+	var columns []int
+	for _, name := range names {
+		if col := v.column(name); col >= 0 {
+			columns = append(columns, col)
+		}
+	}
+
+	v.table.Lock()
+	v.header.Lock()
+	defer v.table.Unlock()
+	defer v.header.Unlock()
+
+	v.columns = columns
+	v.table.SetColumns(columns)
+	v.header.SetColumns(columns)
+	v.resizeColumns()
+}
+
+// SetAlignments sets the alignment of each column of the table.
+func (v *TableView) SetAlignments(alignments []tui.Alignment) {
+	v.table.Lock()
+	v.header.Lock()
+	defer v.table.Unlock()
+	defer v.header.Unlock()
 
-	if v.colWidth != nil {
-		cw := (w - v.tw) / len(v.resized)
+	v.table.SetAlignments(alignments)
+	v.header.SetAlignments(alignments)
+}
+
+// SetKeyColumns sets the columns which identify an entry of the table.
+// The selection stays on the same entry when the rows are updated.
+func (v *TableView) SetKeyColumns(columns ...int) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetKeyColumns(columns...)
+}
 
-		for _, i := range v.resized {
-			v.colWidth[i] = cw
+// SetSortItems sets the indexes of the items from the ItemsList
+// to sort by when a column header is clicked, -1 for columns which
+// can't be sorted. The indexes are in the order of the header columns.
+func (v *TableView) SetSortItems(items []int) {
+	v.sortItems = items
+}
+
+// OnClick selects the clicked row, or returns the item to sort
+// by if a column header is clicked.
+func (v *TableView) OnClick(event gui.Event) int {
+	click := event.Payload.(gui.Click)
+
+	v.header.Lock()
+	if v.header.RowAt(click.Y) >= 0 {
+		col := v.header.ColumnAt(click.X)
+		v.header.Unlock()
+		if col < 0 {
+			return -1
+		}
+		if v.columns != nil {
+			col = v.columns[col]
+		}
+		if col >= len(v.sortItems) {
+			return -1
 		}
+		return v.sortItems[col]
+	}
+	v.header.Unlock()
+
+	v.table.Lock()
+	defer v.table.Unlock()
+	if row := v.table.RowAt(click.Y); row >= 0 {
+		v.table.Select(row)
+	}
+	return -1
+}
 
-		v.table.Table.ColumnWidths = v.colWidth
-		v.header.Table.ColumnWidths = v.colWidth
+// Details returns the values of the selected entry
+// with one displayed column per line.
+func (v *TableView) Details() string {
+	v.table.Lock()
+	entry := v.table.SelectedEntry()
+	v.table.Unlock()
+	if len(entry) == 0 {
+		return ""
+	}
+
+	header := v.VisibleColumns()
+	if header == nil {
+		header = v.Columns()
+	}
+	b := new(strings.Builder)
+	tw := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	for i, name := range header {
+		fmt.Fprint(tw, name)
+		for _, row := range entry {
+			if i < len(row) && row[i] != "" {
+				fmt.Fprintf(tw, "\t%s", row[i])
+			}
+		}
+		fmt.Fprintln(tw)
 	}
+	tw.Flush()
+	return b.String()
+}
+
+// SelectedRows returns all columns of the rows of the selected entry.
+func (v *TableView) SelectedRows() xtui.TableRows {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	return v.table.SelectedRows()
+}
+
+// SetHighlighter sets the highlighter of the changed
+// table cells, nil turns the highlighting off.
+func (v *TableView) SetHighlighter(h xtui.Highlighter) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetHighlighter(h)
+}
+
+// SetEntryHighlighter sets the highlighter of the
+// table entries, nil turns the highlighting off.
+func (v *TableView) SetEntryHighlighter(h xtui.EntryHighlighter) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetEntryHighlighter(h)
+}
+
+// SetGrouping groups the entries of the table, nil ungroups them.
+func (v *TableView) SetGrouping(g *xtui.Grouping) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetGrouping(g)
+}
+
+// ToggleGroup expands or collapses the group of the selected entry.
+func (v *TableView) ToggleGroup() {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.ToggleGroup()
+}
+
+// SetFilterFields sets the fields which can be compared in
+// the filter, replacing the columns of the header.
+func (v *TableView) SetFilterFields(fields map[string]xtui.Cell) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetFilterFields(fields)
+}
+
+// FilterError returns the error of parsing the filter, if any.
+func (v *TableView) FilterError() error {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	return v.table.FilterError()
 }
 
 // Filter applies the filter from the gui.Event to the xtui.Table.
@@ -116,6 +542,32 @@ func (v *TableView) Filter(event gui.Event) {
 	}
 }
 
+// Search highlights the entries containing the text from the gui.Event.
+func (v *TableView) Search(event gui.Event) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.SetSearch(event.Payload.(string))
+}
+
+// NextMatch selects the next entry matching the search,
+// or the previous one if forward is false.
+func (v *TableView) NextMatch(forward bool) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	v.table.NextMatch(forward)
+}
+
+// Matches returns the number of the selected matching
+// entry and the number of the matching entries.
+func (v *TableView) Matches() (current, total int) {
+	v.table.Lock()
+	defer v.table.Unlock()
+
+	return v.table.Matches()
+}
+
 // OnScrollEvent handles the scroll event based on the key pressed.
 func (v *TableView) OnScrollEvent(event gui.Event) {
 	switch event.Payload.(string) {
@@ -127,21 +579,43 @@ func (v *TableView) OnScrollEvent(event gui.Event) {
 		v.table.PageDown()
 	case gui.KeyPgup:
 		v.table.PageUp()
+	case gui.KeyPanLeft, gui.KeyPanRight:
+		delta := 1
+		if event.Payload.(string) == gui.KeyPanLeft {
+			delta = -1
+		}
+		v.table.Lock()
+		v.header.Lock()
+		v.scrollColumns(delta)
+		v.header.Unlock()
+		v.table.Unlock()
 	}
 }
 
-// Update updates the table rows.
+// Update updates the table rows, which are xtui.TableRows or Entries.
 // The lock from the table is used.
 func (v *TableView) Update(payload interface{}) {
-	rows := payload.(xtui.TableRows)
-
 	v.table.Lock()
-	v.table.Rows = rows
-	v.table.Unlock()
+	defer v.table.Unlock()
 
+	switch p := payload.(type) {
+	case xtui.TableRows:
+		v.table.SetRows(p)
+	case Entries:
+		v.table.SetEntries(p.Rows, p.Values)
+	}
 }
 
 // Widgets returns all widgets to be drawed by this view.
 func (v *TableView) Widgets() []tui.Drawable { return []tui.Drawable{v.table, v.header} }
+
 // ItemsList returns a list with names based on which the table can be sorted.
-func (v *TableView) ItemsList() []string     { return v.itemsList }
+func (v *TableView) ItemsList() []string { return v.itemsList }
+
+func sum(values []int) int {
+	s := 0
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
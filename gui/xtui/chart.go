@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"image"
+	"time"
+
+	"github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// chart layout.
+const (
+	// chartLabelWidth is the width of the y axis labels.
+	chartLabelWidth = 10
+	// chartLabelGap is the number of rows between the y axis labels.
+	chartLabelGap = 3
+	// chartMinWindow is the least number of samples displayed when zoomed in.
+	chartMinWindow = 10
+)
+
+// Series is a named series of values and the times they were sampled.
+type Series struct {
+	Label  string
+	Values []float64
+	// Times are the times of the values, the time axis is
+	// not labeled if the series has no times.
+	Times []time.Time
+}
+
+// Chart extends the termui Plot with the axes labeled by values with
+// the unit and by the time, a legend of the series and a zoom of the time
+// window. The y axis is scaled to the maximum of the displayed values.
+type Chart struct {
+	termui.Block
+	plot *widgets.Plot
+
+	series []Series
+	// window is the number of the displayed samples, 0 for all.
+	window int
+
+	// Unit is the unit of the values.
+	Unit string
+	// Colors are the colors of the series, repeated if there are more series.
+	Colors []termui.Color
+	// AxesStyle is the style of the axes and their labels.
+	AxesStyle termui.Style
+}
+
+// NewChart returns a chart without any series.
+func NewChart() *Chart {
+	c := &Chart{
+		Block:     *termui.NewBlock(),
+		plot:      widgets.NewPlot(),
+		Colors:    []termui.Color{termui.ColorGreen, termui.ColorYellow, termui.ColorCyan, termui.ColorMagenta},
+		AxesStyle: termui.NewStyle(termui.ColorWhite),
+	}
+	c.plot.Border = false
+	c.plot.ShowAxes = false
+	return c
+}
+
+// SetSeries replaces the displayed series, keeping the zoom.
+func (c *Chart) SetSeries(series []Series) {
+	c.series = series
+}
+
+// ResetZoom displays all samples of the series.
+func (c *Chart) ResetZoom() {
+	c.window = 0
+}
+
+// Zoom halves the displayed time window if in is true,
+// otherwise it doubles it up to all the samples.
+func (c *Chart) Zoom(in bool) {
+	samples := c.samples()
+	window := c.window
+	if window == 0 {
+		window = samples
+	}
+	if in {
+		window /= 2
+		if window < chartMinWindow {
+			window = chartMinWindow
+		}
+	} else {
+		window *= 2
+	}
+	if window >= samples {
+		window = 0
+	}
+	c.window = window
+}
+
+// samples returns the number of samples of the longest series.
+func (c *Chart) samples() int {
+	n := 0
+	for _, s := range c.series {
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+	}
+	return n
+}
+
+// visible returns the displayed values of the series, resampled to at
+// most width values. The resampled value is the maximum of the merged
+// values, so the peaks are not lost.
+func (c *Chart) visible(values []float64, width int) []float64 {
+	if c.window > 0 && len(values) > c.window {
+		values = values[len(values)-c.window:]
+	}
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	resampled := make([]float64, width)
+	for i := range resampled {
+		from, to := i*len(values)/width, (i+1)*len(values)/width
+		for j := from; j < to; j++ {
+			if j == from || values[j] > resampled[i] {
+				resampled[i] = values[j]
+			}
+		}
+	}
+	return resampled
+}
+
+// Draw implements the termui.Drawable interface.
+func (c *Chart) Draw(buf *termui.Buffer) {
+	c.Block.Draw(buf)
+
+	// the legend is on the first row, the time labels on the last one.
+	area := image.Rect(c.Inner.Min.X+chartLabelWidth+1, c.Inner.Min.Y+1, c.Inner.Max.X, c.Inner.Max.Y-2)
+	if area.Dx() < 2 || area.Dy() < 2 {
+		return
+	}
+
+	var data [][]float64
+	var colors []termui.Color
+	max, samples := 0.0, 0
+	x := c.Inner.Min.X
+	for i, s := range c.series {
+		color := termui.SelectColor(c.Colors, i)
+		label := "─ " + s.Label
+		buf.SetString(label, termui.NewStyle(color), image.Pt(x, c.Inner.Min.Y))
+		x += len([]rune(label)) + 2
+
+		values := c.visible(s.Values, area.Dx())
+		// a line needs at least two points.
+		if len(values) < 2 {
+			continue
+		}
+		for _, v := range values {
+			if v > max {
+				max = v
+			}
+		}
+		if len(values) > samples {
+			samples = len(values)
+		}
+		data = append(data, values)
+		colors = append(colors, color)
+	}
+	if max == 0 {
+		max = 1
+	}
+
+	c.drawAxes(buf, area, max)
+	if len(data) == 0 {
+		return
+	}
+	c.drawTimeLabels(buf, area)
+
+	c.plot.Data = data
+	c.plot.LineColors = colors
+	c.plot.MaxVal = max
+	c.plot.HorizontalScale = 1
+	if samples > 1 {
+		if scale := (area.Dx() - 1) / (samples - 1); scale > 1 {
+			c.plot.HorizontalScale = scale
+		}
+	}
+	// the lines end at the right of the area, the inner
+	// area of the plot is the area of the lines.
+	left := area.Max.X - (samples-1)*c.plot.HorizontalScale - 1
+	c.plot.SetRect(left-1, area.Min.Y-1, area.Max.X+1, area.Max.Y+1)
+	c.plot.Draw(buf)
+}
+
+// drawAxes draws the axes left and below the area,
+// the y axis is labeled by the values up to max.
+func (c *Chart) drawAxes(buf *termui.Buffer, area image.Rectangle, max float64) {
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		buf.SetCell(termui.NewCell(termui.VERTICAL_LINE, c.AxesStyle), image.Pt(area.Min.X-1, y))
+	}
+	buf.SetCell(termui.NewCell(termui.BOTTOM_LEFT, c.AxesStyle), image.Pt(area.Min.X-1, area.Max.Y))
+	for x := area.Min.X; x < area.Max.X; x++ {
+		buf.SetCell(termui.NewCell(termui.HORIZONTAL_LINE, c.AxesStyle), image.Pt(x, area.Max.Y))
+	}
+
+	// the rows are labeled from the bottom one.
+	for row := 0; row < area.Dy(); row += chartLabelGap {
+		v := max * float64(row) / float64(area.Dy()-1)
+		label := FormatNumber(v, c.Unit)
+		if len(label) > chartLabelWidth {
+			label = label[:chartLabelWidth]
+		}
+		pad := chartLabelWidth - len([]rune(label))
+		buf.SetString(label, c.AxesStyle, image.Pt(c.Inner.Min.X+pad, area.Max.Y-1-row))
+	}
+}
+
+// drawTimeLabels labels the x axis by the time before the last of the
+// samples, which is drawn at the right. The samples are drawn evenly
+// spaced, so the labels are the times of the first and the middle
+// displayed samples of the longest series.
+func (c *Chart) drawTimeLabels(buf *termui.Buffer, area image.Rectangle) {
+	y := area.Max.Y + 1
+	buf.SetString("now", c.AxesStyle, image.Pt(area.Max.X-3, y))
+
+	times := c.times()
+	if len(times) < 2 {
+		return
+	}
+	last := times[len(times)-1]
+	buf.SetString("-"+last.Sub(times[0]).Round(time.Second).String(), c.AxesStyle, image.Pt(area.Min.X, y))
+	if mid := "-" + last.Sub(times[len(times)/2]).Round(time.Second).String(); area.Dx() > 4*len(mid) {
+		buf.SetString(mid, c.AxesStyle, image.Pt(area.Min.X+(area.Dx()-len(mid))/2, y))
+	}
+}
+
+// times returns the displayed times of the longest series.
+func (c *Chart) times() []time.Time {
+	var times []time.Time
+	for _, s := range c.series {
+		if len(s.Times) > len(times) {
+			times = s.Times
+		}
+	}
+	if c.window > 0 && len(times) > c.window {
+		times = times[len(times)-c.window:]
+	}
+	return times
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChart_Zoom(t *testing.T) {
+	chart := NewChart()
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	chart.SetSeries([]Series{{Label: "rx", Values: values}})
+
+	chart.Zoom(true)
+	if got := chart.visible(values, 100); len(got) != 50 || got[0] != 50 {
+		t.Errorf("Error occured got:%v; want:%v", len(got), 50)
+	}
+	chart.Zoom(true)
+	chart.Zoom(true)
+	chart.Zoom(true)
+	if chart.window != chartMinWindow {
+		t.Errorf("Error occured got:%v; want:%v", chart.window, chartMinWindow)
+	}
+	for i := 0; i < 4; i++ {
+		chart.Zoom(false)
+	}
+	if chart.window != 0 {
+		t.Errorf("Error occured got:%v; want:%v", chart.window, 0)
+	}
+
+	// the peaks are kept when the values are resampled.
+	got := chart.visible([]float64{1, 5, 2, 2, 0, 3}, 3)
+	if want := []float64{5, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
+
+func TestChart_Times(t *testing.T) {
+	chart := NewChart()
+	start := time.Now()
+	times := make([]time.Time, 20)
+	for i := range times {
+		times[i] = start.Add(time.Duration(i) * time.Second)
+	}
+	// the interval changed to 5s for the last samples.
+	times = append(times, times[19].Add(5*time.Second), times[19].Add(10*time.Second))
+	chart.SetSeries([]Series{{Label: "rx", Values: make([]float64, len(times)), Times: times}})
+
+	chart.window = chartMinWindow
+	got := chart.times()
+	if span := got[len(got)-1].Sub(got[0]); span != 17*time.Second {
+		t.Errorf("Error occured got:%v; want:%v", span, 17*time.Second)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value float64
+		unit  string
+		want  string
+	}{
+		{value: 0, unit: "", want: "0"},
+		{value: 999, unit: "bps", want: "999 bps"},
+		{value: 1500, unit: "bps", want: "1.5 kbps"},
+		{value: 2.5e9, unit: "B", want: "2.5 GB"},
+		{value: 12.34, unit: "/s", want: "12.3 /s"},
+		{value: 1234567, unit: "", want: "1.2M"},
+	}
+	for _, test := range tests {
+		if got := FormatNumber(test.value, test.unit); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+
+	if got, want := FormatBinary(1536, "B"), "1.5 KiB"; got != want {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+	if got, _ := ParseNumber(FormatBinary(3<<20, "B")); got != 3<<20 {
+		t.Errorf("Error occured got:%v; want:%v", got, 3<<20)
+	}
+}
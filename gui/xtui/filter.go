@@ -0,0 +1,349 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Cell locates a value within the rows of a table entry.
+type Cell struct {
+	// Row is the row of the entry, 0 for the first row.
+	Row int
+	// Column is the column of the row.
+	Column int
+}
+
+// Matcher reports whether the entry, given by its rows, matches the filter.
+type Matcher func(entry TableRows) bool
+
+// operatorChars are the characters which make the filter an expression.
+// A filter without any of them is matched as plain text.
+const operatorChars = "~=!<>()&|"
+
+// ParseFilter parses the filter expression. The expression consists of
+// terms joined by && (also a space) or ||, terms can be negated with !
+// and grouped by parentheses. A term is either a comparison of a field
+// with a value, or a plain word matched against the column.
+//
+// Supported comparisons are ~ and !~ for a regular expression match,
+// = and != for equality, numeric if the value is a number, and
+// <, <=, >, >= for numeric comparison. Numbers can have the k, M, G
+// and T suffixes. Values containing spaces can be quoted.
+//
+// Fields are matched case-insensitively. Plain words are matched
+// as substrings of the column, in any column if column is negative.
+func ParseFilter(filter string, fields map[string]Cell, column int) (Matcher, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	if !strings.ContainsAny(filter, operatorChars) {
+		return contains(filter, column), nil
+	}
+
+	p := &filterParser{s: filter, fields: fields, column: column}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+	return m, nil
+}
+
+// filterParser is a recursive descent parser of the filter expressions.
+type filterParser struct {
+	s      string
+	pos    int
+	fields map[string]Cell
+	column int
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func (p *filterParser) skipSpaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *filterParser) parseOr() (Matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !strings.HasPrefix(p.s[p.pos:], "||") {
+			return left, nil
+		}
+		p.pos += 2
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(entry TableRows) bool { return l(entry) || right(entry) }
+	}
+}
+
+func (p *filterParser) parseAnd() (Matcher, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		rest := p.s[p.pos:]
+		if rest == "" || rest[0] == ')' || strings.HasPrefix(rest, "||") {
+			return left, nil
+		}
+		if strings.HasPrefix(rest, "&&") {
+			p.pos += 2
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(entry TableRows) bool { return l(entry) && right(entry) }
+	}
+}
+
+func (p *filterParser) parseUnary() (Matcher, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end")
+	}
+	switch p.s[p.pos] {
+	case '!':
+		p.pos++
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(entry TableRows) bool { return !m(entry) }, nil
+	case '(':
+		p.pos++
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.s) || p.s[p.pos] != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+		return m, nil
+	}
+	return p.parseTerm()
+}
+
+// filterOperators are the comparison operators,
+// the longer ones are listed first.
+var filterOperators = []string{"!~", "!=", "==", ">=", "<=", "~", "=", ">", "<"}
+
+func (p *filterParser) parseTerm() (Matcher, error) {
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ' ' && !strings.ContainsRune(operatorChars, rune(p.s[p.pos])) {
+		p.pos++
+	}
+	word := p.s[start:p.pos]
+	if word == "" {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+
+	p.skipSpaces()
+	op := ""
+	for _, o := range filterOperators {
+		if strings.HasPrefix(p.s[p.pos:], o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		p.pos = start + len(word)
+		return contains(word, p.column), nil
+	}
+
+	cell, ok := p.fields[strings.ToLower(word)]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown field %q", word)
+	}
+	p.pos += len(op)
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "~", "!~":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regexp %q: %v", value, err)
+		}
+		negate := op == "!~"
+		return func(entry TableRows) bool {
+			return re.MatchString(cellValue(entry, cell)) != negate
+		}, nil
+	case "=", "==", "!=":
+		negate := op == "!="
+		n, numeric := ParseNumber(value)
+		return func(entry TableRows) bool {
+			s := cellValue(entry, cell)
+			if v, ok := ParseNumber(s); ok && numeric {
+				return (v == n) != negate
+			}
+			return strings.EqualFold(s, value) != negate
+		}, nil
+	}
+
+	n, ok := ParseNumber(value)
+	if !ok {
+		return nil, fmt.Errorf("%s expects a number, got %q", op, value)
+	}
+	return func(entry TableRows) bool {
+		v, ok := ParseNumber(cellValue(entry, cell))
+		if !ok {
+			return false
+		}
+		switch op {
+		case ">":
+			return v > n
+		case ">=":
+			return v >= n
+		case "<":
+			return v < n
+		default:
+			return v <= n
+		}
+	}, nil
+}
+
+// parseValue parses the value of a comparison, which is either quoted
+// or ends before a space, && or || or an unbalanced ).
+func (p *filterParser) parseValue() (string, error) {
+	p.skipSpaces()
+	if p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+		quote := p.s[p.pos]
+		end := strings.IndexByte(p.s[p.pos+1:], quote)
+		if end < 0 {
+			return "", p.errorf("missing %c", quote)
+		}
+		value := p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+
+	start, depth := p.pos, 0
+	for p.pos < len(p.s) {
+		rest := p.s[p.pos:]
+		if rest[0] == ' ' || rest[0] == ')' && depth == 0 || strings.HasPrefix(rest, "&&") || strings.HasPrefix(rest, "||") {
+			break
+		}
+		switch rest[0] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("missing value")
+	}
+	return p.s[start:p.pos], nil
+}
+
+// contains returns a matcher of the entries containing the text in
+// the column of the first row, or in any column if column is negative.
+func contains(text string, column int) Matcher {
+	return func(entry TableRows) bool {
+		if len(entry) == 0 {
+			return false
+		}
+		if column >= 0 {
+			return column < len(entry[0]) && strings.Contains(entry[0][column], text)
+		}
+		for _, cell := range entry[0] {
+			if strings.Contains(cell, text) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// cellValue returns the value of the cell in the entry,
+// or an empty string if the entry has no such cell.
+func cellValue(entry TableRows, cell Cell) string {
+	if cell.Row >= len(entry) || cell.Column >= len(entry[cell.Row]) {
+		return ""
+	}
+	return strings.TrimSpace(entry[cell.Row][cell.Column])
+}
+
+// siSuffixes are the multipliers of the number suffixes.
+var siSuffixes = map[byte]float64{
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+	'T': 1e12,
+}
+
+// binarySuffixes are the multipliers of the
+// number suffixes followed by i, e.g. Mi.
+var binarySuffixes = map[byte]float64{
+	'k': 1 << 10,
+	'K': 1 << 10,
+	'M': 1 << 20,
+	'G': 1 << 30,
+	'T': 1 << 40,
+}
+
+// ParseNumber parses a number with an optional suffix, followed
+// by an optional unit, e.g. 1500, 1.5k, 2.3 Mpps or 4 GiB.
+func ParseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.' || s[end] == '-' && end == 0) {
+		end++
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+	rest := strings.TrimSpace(s[end:])
+	if rest != "" {
+		if m, ok := siSuffixes[rest[0]]; ok {
+			if len(rest) > 1 && rest[1] == 'i' {
+				m = binarySuffixes[rest[0]]
+			}
+			n *= m
+		}
+	}
+	return n, true
+}
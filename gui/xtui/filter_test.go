@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	fields := map[string]Cell{
+		"name":  {Row: 0, Column: 0},
+		"state": {Row: 0, Column: 1},
+		"rxpps": {Row: 1, Column: 2},
+	}
+	gig := TableRows{{"GigabitEthernet0/8/0", "up", ""}, {"", "", "1500"}}
+	loop := TableRows{{"loop0", "down", ""}, {"", "", "0"}}
+	tap := TableRows{{"tap0 x", "up", ""}, {"", "", "2.5 Mpps"}}
+
+	tests := []struct {
+		filter string
+		want   []bool
+	}{
+		{filter: "", want: nil},
+		{filter: "loop", want: []bool{false, true, false}},
+		{filter: "tap0 x", want: []bool{false, false, true}},
+		{filter: "name~^Gig state=up rxpps>1000", want: []bool{true, false, false}},
+		{filter: "name~^Gig && rxpps>1k", want: []bool{true, false, false}},
+		{filter: "rxpps>=1.5k", want: []bool{true, false, true}},
+		{filter: "rxpps > 2M", want: []bool{false, false, true}},
+		{filter: "STATE=down || rxpps=1500", want: []bool{true, true, false}},
+		{filter: "!(state=up)", want: []bool{false, true, false}},
+		{filter: "state!=up", want: []bool{false, true, false}},
+		{filter: "name!~^(loop|tap)", want: []bool{true, false, false}},
+		{filter: "name~'tap0 x'", want: []bool{false, false, true}},
+		{filter: "(loop || Gig) !rxpps<1", want: []bool{true, false, false}},
+	}
+
+	for _, test := range tests {
+		m, err := ParseFilter(test.filter, fields, 0)
+		if err != nil {
+			t.Errorf("Error occured for %q: %v", test.filter, err)
+			continue
+		}
+		if m == nil {
+			if test.want != nil {
+				t.Errorf("Error occured for %q got no filter", test.filter)
+			}
+			continue
+		}
+		for i, entry := range []TableRows{gig, loop, tap} {
+			if got := m(entry); got != test.want[i] {
+				t.Errorf("Error occured for %q entry %d got:%v; want:%v", test.filter, i, got, test.want[i])
+			}
+		}
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	fields := map[string]Cell{"calls": {Row: 0, Column: 1}}
+	for _, filter := range []string{
+		"calls>",
+		"calls>x",
+		"clocks>0",
+		"(calls>0",
+		"calls>0)",
+		"calls~(",
+		"node &&",
+		"calls='0",
+	} {
+		if _, err := ParseFilter(filter, fields, 0); err == nil {
+			t.Errorf("Error occured for %q: want parse error", filter)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import "fmt"
+
+// group marks and the indentation of the grouped entries.
+const (
+	groupCollapsed = "▸"
+	groupExpanded  = "▾"
+	groupIndent    = "  "
+)
+
+// Grouping describes how the entries of the table are grouped.
+// Each group is displayed as a header entry with the number of
+// entries and their aggregated values, followed by the entries
+// if the group is expanded. The groups are ordered by their first
+// entry and a group with a single entry is displayed as the entry.
+type Grouping struct {
+	// Column is the column of the first row in which the group
+	// name is displayed and the grouped entries are indented.
+	Column int
+	// Group returns the name of the group of the entry, or an
+	// empty string if the entry doesn't belong to any group.
+	Group func(entry TableRows) string
+	// Aggregate returns the cells of the group header with the values
+	// aggregated from the values of the grouped entries set by
+	// SetEntries. The entries of rows set by SetRows are nil.
+	Aggregate func(entries []interface{}) map[Cell]string
+}
+
+// SetGrouping groups the entries of the table, nil ungroups them.
+// The groups are collapsed until they are expanded by ToggleGroup.
+func (t *Table) SetGrouping(g *Grouping) {
+	t.grouping = g
+	if t.expanded == nil {
+		t.expanded = make(map[string]bool)
+	}
+}
+
+// ToggleGroup expands or collapses the group of the selected
+// entry. The header of the group is selected.
+func (t *Table) ToggleGroup() {
+	selected := t.offset + t.curr
+	start := selected - selected%t.rowsPerEntry
+	name, ok := t.groupOf[start]
+	if !ok {
+		return
+	}
+	t.expanded[name] = !t.expanded[name]
+
+	header := start
+	for !t.isHeader[header] && header > 0 {
+		header -= t.rowsPerEntry
+	}
+	t.prev = t.curr
+	if header >= t.offset {
+		t.curr = header - t.offset
+	} else {
+		t.offset = header
+		t.curr = 0
+	}
+	t.keepPosition = true
+	t.paintActiveRow()
+}
+
+// group returns the rows with the entries grouped, and the changes of
+// the rows in the same order. The indexes of the entries of the rows
+// in the table rows are nil if they are the table rows.
+func (t *Table) group(rows TableRows, changes [][]Change, indexes []int) (TableRows, [][]Change) {
+	t.groupOf = make(map[int]string)
+	t.isHeader = make(map[int]bool)
+	if t.grouping == nil {
+		return rows, changes
+	}
+
+	type group struct {
+		name    string
+		entries []int
+	}
+	var groups []*group
+	byName := make(map[string]*group)
+	for start := 0; start+t.rowsPerEntry <= len(rows); start += t.rowsPerEntry {
+		name := t.grouping.Group(rows[start : start+t.rowsPerEntry])
+		g, ok := byName[name]
+		if !ok || name == "" {
+			g = &group{name: name}
+			groups = append(groups, g)
+			if name != "" {
+				byName[name] = g
+			}
+		}
+		g.entries = append(g.entries, start)
+	}
+
+	var out TableRows
+	var outChanges [][]Change
+	for _, g := range groups {
+		if g.name == "" || len(g.entries) == 1 {
+			for r := 0; r < t.rowsPerEntry; r++ {
+				out = append(out, rows[g.entries[0]+r])
+				outChanges = append(outChanges, rowChanges(changes, g.entries[0]+r))
+			}
+			continue
+		}
+
+		t.groupOf[len(out)] = g.name
+		t.isHeader[len(out)] = true
+		out = append(out, t.aggregate(g.name, g.entries, rows, indexes)...)
+		outChanges = append(outChanges, make([][]Change, t.rowsPerEntry)...)
+		if !t.expanded[g.name] {
+			continue
+		}
+		for _, start := range g.entries {
+			t.groupOf[len(out)] = g.name
+			for r := 0; r < t.rowsPerEntry; r++ {
+				row := rows[start+r]
+				if r == 0 && t.grouping.Column < len(row) {
+					row = append([]string(nil), row...)
+					row[t.grouping.Column] = groupIndent + row[t.grouping.Column]
+				}
+				out = append(out, row)
+				outChanges = append(outChanges, rowChanges(changes, start+r))
+			}
+		}
+	}
+	return out, outChanges
+}
+
+// aggregate returns the header rows of the group with the entries
+// starting at the rows.
+func (t *Table) aggregate(name string, entries []int, rows TableRows, indexes []int) TableRows {
+	header := make(TableRows, t.rowsPerEntry)
+	for r := range header {
+		header[r] = make([]string, len(rows[entries[0]+r]))
+	}
+
+	mark := groupCollapsed
+	if t.expanded[name] {
+		mark = groupExpanded
+	}
+	if t.grouping.Column < len(header[0]) {
+		header[0][t.grouping.Column] = fmt.Sprintf("%s %s (%d)", mark, name, len(entries))
+	}
+
+	if t.grouping.Aggregate == nil {
+		return header
+	}
+	values := make([]interface{}, len(entries))
+	for i, start := range entries {
+		entry := start / t.rowsPerEntry
+		if indexes != nil {
+			entry = indexes[entry]
+		}
+		if entry < len(t.entries) {
+			values[i] = t.entries[entry]
+		}
+	}
+	for cell, value := range t.grouping.Aggregate(values) {
+		if cell.Row < t.rowsPerEntry && cell.Column < len(header[cell.Row]) {
+			header[cell.Row][cell.Column] = value
+		}
+	}
+	return header
+}
+
+// rowChanges returns the changes of the row, nil if there are none.
+func rowChanges(changes [][]Change, row int) []Change {
+	if row < len(changes) {
+		return changes[row]
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTable_Group(t *testing.T) {
+	table := NewTable()
+	table.InitFilter(0, 1)
+	table.SetGrouping(&Grouping{
+		Column: 0,
+		Group:  func(entry TableRows) string { return entry[0][1] },
+		Aggregate: func(entries []interface{}) map[Cell]string {
+			sum := 0
+			for _, e := range entries {
+				sum += e.(int)
+			}
+			return map[Cell]string{{Row: 0, Column: 2}: strconv.Itoa(sum)}
+		},
+	})
+	rows := TableRows{{"a", "x", "1"}, {"b", "y", "2"}, {"c", "x", "3"}, {"d", "", "4"}}
+	table.SetEntries(rows, []interface{}{1, 2, 3, 4})
+
+	// the groups are collapsed and ordered by their first entry.
+	got, _ := table.group(rows, nil, nil)
+	want := TableRows{{"▸ x (2)", "", "4"}, {"b", "y", "2"}, {"d", "", "4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+
+	table.out = got
+	table.visibleRows = 3
+	table.ToggleGroup()
+	got, _ = table.group(rows, nil, nil)
+	want = TableRows{{"▾ x (2)", "", "4"}, {"  a", "x", "1"}, {"  c", "x", "3"}, {"b", "y", "2"}, {"d", "", "4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+
+	// toggling a member collapses its group and selects the header.
+	table.out = got
+	table.curr = 2
+	table.ToggleGroup()
+	if table.curr != 0 || table.expanded["x"] {
+		t.Errorf("Error occured got:%v; want:%v", table.curr, 0)
+	}
+
+	// the filtered entries are aggregated from their values.
+	table.SetEntries(append(rows, []string{"e", "x", "5"}), []interface{}{1, 2, 3, 4, 5})
+	got, _ = table.group(TableRows{{"b", "y", "2"}, {"c", "x", "3"}, {"e", "x", "5"}}, nil, []int{1, 2, 4})
+	want = TableRows{{"b", "y", "2"}, {"▸ x (2)", "", "8"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Error occured got:%v; want:%v", got, want)
+	}
+}
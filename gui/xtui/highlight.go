@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"image"
+	"strconv"
+
+	"github.com/gizak/termui/v3"
+)
+
+// Change describes how the value of a cell changed
+// since the previous update of the table rows.
+type Change int
+
+// cell changes.
+const (
+	Unchanged Change = iota
+	Changed
+	Increased
+	Decreased
+)
+
+// Highlighter returns the style of the cell at the position within
+// the entry, given its value and change since the previous update.
+// If the cell shouldn't be highlighted ok is false.
+type Highlighter func(cell Cell, value string, change Change) (style termui.Style, ok bool)
+
+// EntryHighlighter returns the style of the rows of the entry.
+// If the entry shouldn't be highlighted ok is false.
+type EntryHighlighter func(entry TableRows) (style termui.Style, ok bool)
+
+// SetEntryHighlighter sets the highlighter of the entries, nil turns
+// the highlighting off. The rows of the group headers aren't highlighted.
+func (t *Table) SetEntryHighlighter(h EntryHighlighter) {
+	t.entryHighlighter = h
+}
+
+// SetHighlighter sets the highlighter of the cells, nil turns
+// the highlighting off.
+func (t *Table) SetHighlighter(h Highlighter) {
+	t.highlighter = h
+	if h == nil {
+		t.changes = nil
+	}
+}
+
+// SetRows replaces the rows of the table. If the table has a highlighter,
+// the cells are compared to the cells of the same entry in the previous rows.
+func (t *Table) SetRows(rows TableRows) {
+	t.SetEntries(rows, nil)
+}
+
+// SetEntries replaces the rows of the table and the values of the
+// entries they were formatted from, one value per entry, which are
+// aggregated by the grouping.
+func (t *Table) SetEntries(rows TableRows, entries []interface{}) {
+	if t.highlighter != nil {
+		t.changes = t.compare(t.Rows, rows)
+	}
+	t.Rows = rows
+	t.entries = entries
+}
+
+// compare returns the changes of the cells of the entries in rows,
+// the entries are matched to the previous rows by their key.
+func (t *Table) compare(prev, rows TableRows) [][]Change {
+	entries := make(map[string]int)
+	for start := 0; start < len(prev); start += t.rowsPerEntry {
+		entries[t.compareKey(prev, start)] = start
+	}
+
+	changes := make([][]Change, len(rows))
+	for start := 0; start < len(rows); start += t.rowsPerEntry {
+		prevStart, ok := entries[t.compareKey(rows, start)]
+		if !ok {
+			continue
+		}
+		for r := 0; r < t.rowsPerEntry && start+r < len(rows) && prevStart+r < len(prev); r++ {
+			row, prevRow := rows[start+r], prev[prevStart+r]
+			for col := range row {
+				if col >= len(prevRow) || row[col] == prevRow[col] {
+					continue
+				}
+				if changes[start+r] == nil {
+					changes[start+r] = make([]Change, len(row))
+				}
+				changes[start+r][col] = change(prevRow[col], row[col])
+			}
+		}
+	}
+	return changes
+}
+
+// compareKey returns the key of the entry starting at the row,
+// which is its index if the table has no key columns.
+func (t *Table) compareKey(rows TableRows, start int) string {
+	if key := t.entryKey(rows[start]); key != "" {
+		return key
+	}
+	return strconv.Itoa(start)
+}
+
+// change returns the change of the value, which is
+// compared numerically if both values are numbers.
+func change(prev, value string) Change {
+	p, ok := ParseNumber(prev)
+	v, ok2 := ParseNumber(value)
+	switch {
+	case !ok || !ok2 || p == v:
+		return Changed
+	case v > p:
+		return Increased
+	default:
+		return Decreased
+	}
+}
+
+// paintCells paints the displayed cells highlighted by the highlighter.
+// The selected row isn't highlighted.
+func (t *Table) paintCells(buf *termui.Buffer, outChanges [][]Change) {
+	if t.highlighter == nil {
+		return
+	}
+	widths, err := t.ColumnWidths()
+	if err != nil {
+		return
+	}
+	widths = t.scrolledWidths(widths)
+
+	for i := 0; i < t.visibleRows; i++ {
+		if i == t.curr {
+			continue
+		}
+		row := t.offset + i
+		if row >= len(t.out) {
+			return
+		}
+		var changes []Change
+		if row < len(outChanges) {
+			changes = outChanges[row]
+		}
+		y := t.Inner.Min.Y + i
+		x := t.Inner.Min.X
+		for j, width := range widths {
+			col := j
+			if j > 0 {
+				col += t.scroll
+			}
+			if t.columns != nil {
+				if col >= len(t.columns) {
+					break
+				}
+				col = t.columns[col]
+			}
+			if col < len(t.out[row]) {
+				c := Unchanged
+				if col < len(changes) {
+					c = changes[col]
+				}
+				cell := Cell{Row: row % t.rowsPerEntry, Column: col}
+				if style, ok := t.highlighter(cell, t.out[row][col], c); ok {
+					t.restyle(buf, image.Rect(x, y, x+width, y+1), style)
+				}
+			}
+			// each column is followed by a separator.
+			x += width + 1
+		}
+	}
+}
+
+// restyle changes the style of the buffer cells in the rectangle,
+// the background of the row is kept if the style has none.
+func (t *Table) restyle(buf *termui.Buffer, rect image.Rectangle, style termui.Style) {
+	rect = rect.Intersect(t.Inner)
+	for x := rect.Min.X; x < rect.Max.X; x++ {
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			p := image.Pt(x, y)
+			cell := buf.GetCell(p)
+			cell.Style.Fg = style.Fg
+			if style.Bg != termui.ColorClear {
+				cell.Style.Bg = style.Bg
+			}
+			cell.Style.Modifier |= style.Modifier
+			buf.SetCell(cell, p)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gizak/termui/v3"
+)
+
+func TestTable_SetRows(t *testing.T) {
+	table := NewTable()
+	table.InitFilter(0, 2)
+	table.SetHighlighter(func(Cell, string, Change) (termui.Style, bool) {
+		return termui.Style{}, false
+	})
+
+	table.SetRows(TableRows{{"a", "1"}, {"", "x"}, {"b", "5"}, {"", "y"}})
+	// the entries are matched by the name, not the position.
+	table.SetRows(TableRows{{"b", "3"}, {"", "y"}, {"a", "2"}, {"", "z"}, {"c", "1"}, {"", "w"}})
+
+	want := [][]Change{
+		{Unchanged, Decreased},
+		nil,
+		{Unchanged, Increased},
+		{Unchanged, Changed},
+		nil,
+		nil,
+	}
+	if !reflect.DeepEqual(table.changes, want) {
+		t.Errorf("Error occured got:%v; want:%v", table.changes, want)
+	}
+}
@@ -19,6 +19,7 @@ package xtui
 import (
 	"bytes"
 	"errors"
+	"sort"
 	"strings"
 
 	"github.com/gizak/termui/v3"
@@ -61,22 +62,76 @@ type Table struct {
 	filter *bytes.Buffer
 	// column on which the filter should be applied
 	filterColumn int
+	// filterFields are the fields which can be
+	// compared in the filter, keyed by lower case name.
+	filterFields map[string]Cell
+	// matcher is the parsed filter, nil if there is no filter
+	// or it can't be parsed.
+	matcher Matcher
+	// matcherErr is the error of parsing the filter.
+	matcherErr error
+	// parsedFilter is the filter the matcher was parsed from.
+	parsedFilter string
 	// number of rows per entry in the table
 	rowsPerEntry int
+	// columns are the indexes of the displayed columns in the
+	// order they are displayed. If nil all columns are displayed.
+	columns []int
+	// keyColumns identify the entry, the selection follows the entry
+	// with the same key when the rows change. If nil the filter
+	// column is used.
+	keyColumns []int
+	// keepPosition is set if the position was changed explicitly
+	// and the selection should not follow the entry on the next draw.
+	keepPosition bool
+	// alignments of the columns, columns without
+	// alignment are aligned to the left.
+	alignments []termui.Alignment
+	// scroll is the number of displayed columns following
+	// the first one, which are scrolled out of the view.
+	scroll int
+	// highlighter returns the styles of the cells,
+	// nil if the cells aren't highlighted.
+	highlighter Highlighter
+	// entryHighlighter returns the styles of the entries,
+	// nil if the entries aren't highlighted.
+	entryHighlighter EntryHighlighter
+	// changes of the cells of the rows since the previous
+	// update, nil for rows without changes.
+	changes [][]Change
+	// search is the text searched for in the rows,
+	// the entries containing it are highlighted.
+	search string
+	// matches are the indexes of the first rows
+	// of the entries matching the search.
+	matches []int
+	// grouping of the entries, nil if they aren't grouped.
+	grouping *Grouping
+	// entries are the values the entries of the rows were
+	// formatted from, aggregated by the grouping. Nil if
+	// the rows were set without them.
+	entries []interface{}
+	// expanded are the names of the expanded groups.
+	expanded map[string]bool
+	// groupOf are the groups of the displayed entries,
+	// keyed by the first row of the entry.
+	groupOf map[int]string
+	// isHeader is set for the first rows of the group headers.
+	isHeader map[int]bool
 
-	// colors which will be used to paint the table rows.
-	Colors struct {
-		// default color of each row
-		Text termui.Color
-		// text color of the selected row
-		SelectedRowFg termui.Color
-		// row color of the selected row
-		SelectedRowBg termui.Color
+	// styles which will be used to paint the table rows.
+	Styles struct {
+		// default style of each row
+		Text termui.Style
+		// style of the selected row
+		SelectedRow termui.Style
+		// style of the rows matching the search
+		Match termui.Style
 	}
 }
 
 // NewTable returns a default instance of xtui.Table.
-func NewTable(lightTheme bool) *Table {
+func NewTable() *Table {
 	t := &Table{
 		Table:        widgets.NewTable(),
 		out:          nil,
@@ -90,22 +145,58 @@ func NewTable(lightTheme bool) *Table {
 		filterColumn: -1,
 		rowsPerEntry: 1,
 	}
-	// Default colors
-	if lightTheme {
-		t.Colors.Text = termui.ColorBlack
-	} else {
-		t.Colors.Text = termui.ColorWhite
-	}
-	t.Colors.SelectedRowFg = termui.ColorBlack
-	t.Colors.SelectedRowBg = termui.ColorGreen
+	// Default styles
+	t.Styles.Text = termui.Theme.Table.Text
+	t.Styles.SelectedRow = termui.NewStyle(termui.ColorBlack, termui.ColorGreen, termui.ModifierBold)
+	t.Styles.Match = termui.NewStyle(termui.ColorBlack, termui.ColorYellow)
 	return t
 }
 
-// paintActiveRows paints the active row in the
-// specified table.
+// paintActiveRows paints the active row in the specified table.
+// The previously active row gets back its style of a matched or
+// highlighted entry.
 func (t *Table) paintActiveRow() {
-	t.RowStyles[t.prev] = termui.NewStyle(t.Colors.Text)
-	t.RowStyles[t.curr] = termui.NewStyle(t.Colors.SelectedRowFg, t.Colors.SelectedRowBg, termui.ModifierBold)
+	if t.prev != t.curr {
+		t.RowStyles[t.prev] = t.rowStyle(t.prev)
+	}
+	t.RowStyles[t.curr] = t.Styles.SelectedRow
+}
+
+// paintMatches paints the displayed rows, highlighting the rows
+// of the entries matching the search and of the highlighted entries.
+func (t *Table) paintMatches() {
+	for i := 0; i < t.visibleRows; i++ {
+		t.RowStyles[i] = t.rowStyle(i)
+	}
+}
+
+// rowStyle returns the style of the displayed row when it isn't
+// selected: the match style if its entry matches the search, the
+// style of the highlighted entry, or the text style.
+func (t *Table) rowStyle(i int) termui.Style {
+	row := t.offset + i
+	start := row - row%t.rowsPerEntry
+	if t.search != "" {
+		j := sort.SearchInts(t.matches, start)
+		if j < len(t.matches) && t.matches[j] == start {
+			return t.Styles.Match
+		}
+	}
+	if t.entryHighlighter != nil && !t.isHeader[start] && start >= 0 && start+t.rowsPerEntry <= len(t.out) {
+		if style, ok := t.entryHighlighter(t.out[start : start+t.rowsPerEntry]); ok {
+			return style
+		}
+	}
+	return t.Styles.Text
+}
+
+// SetStyles changes the styles used to paint the table rows.
+func (t *Table) SetStyles(text, selectedRow, match termui.Style) {
+	t.Styles.Text = text
+	t.Styles.SelectedRow = selectedRow
+	t.Styles.Match = match
+	t.TextStyle = text
+	t.RowStyles = make(map[int]termui.Style)
 }
 
 // AppendToFilter updates the filter of the table.
@@ -129,6 +220,34 @@ func (t *Table) Filter() string {
 	return t.filter.String()
 }
 
+// SetFilterFields sets the fields which can be compared
+// in the filter expression, keyed by their name.
+func (t *Table) SetFilterFields(fields map[string]Cell) {
+	t.filterFields = make(map[string]Cell, len(fields))
+	for name, cell := range fields {
+		t.filterFields[strings.ToLower(name)] = cell
+	}
+	t.parsedFilter = ""
+	t.matcher, t.matcherErr = nil, nil
+}
+
+// FilterError returns the error of parsing the filter, if any.
+// The rows aren't filtered if the filter can't be parsed.
+func (t *Table) FilterError() error {
+	t.parseFilter()
+	return t.matcherErr
+}
+
+// parseFilter parses the filter, if it changed since the last call.
+func (t *Table) parseFilter() {
+	filter := t.filter.String()
+	if filter == t.parsedFilter {
+		return
+	}
+	t.parsedFilter = filter
+	t.matcher, t.matcherErr = ParseFilter(filter, t.filterFields, t.filterColumn)
+}
+
 // ColumnWidths returns the column widths of the table.
 func (t *Table) ColumnWidths() ([]int, error) {
 	if t.Table.ColumnWidths != nil {
@@ -147,11 +266,264 @@ func (t *Table) ColumnWidths() ([]int, error) {
 	return columnWidths, nil
 }
 
+// SetColumns sets the indexes of the columns to be displayed,
+// in the order they should be displayed. If nil, all columns are displayed.
+func (t *Table) SetColumns(columns []int) {
+	t.columns = columns
+}
+
+// SetAlignments sets the alignment of each column.
+func (t *Table) SetAlignments(alignments []termui.Alignment) {
+	t.alignments = alignments
+}
+
+// align returns a copy of the displayed rows with the cells of the
+// columns aligned to the right or center padded with spaces.
+func (t *Table) align(rows TableRows) TableRows {
+	if len(t.alignments) == 0 || len(t.Table.ColumnWidths) == 0 {
+		return rows
+	}
+	aligned := make(TableRows, len(rows))
+	for i, row := range rows {
+		aligned[i] = make([]string, len(row))
+		for j, cell := range row {
+			aligned[i][j] = cell
+			col := j
+			if t.columns != nil && j < len(t.columns) {
+				col = t.columns[j]
+			}
+			if col >= len(t.alignments) || j >= len(t.Table.ColumnWidths) {
+				continue
+			}
+			pad := t.Table.ColumnWidths[j] - len([]rune(cell))
+			if pad <= 0 || cell == EmptyCell {
+				continue
+			}
+			switch t.alignments[col] {
+			case termui.AlignRight:
+				aligned[i][j] = strings.Repeat(" ", pad) + cell
+			case termui.AlignCenter:
+				aligned[i][j] = strings.Repeat(" ", pad/2) + cell
+			}
+		}
+	}
+	return aligned
+}
+
+// project returns the rows with only the displayed columns.
+func (t *Table) project(rows TableRows) TableRows {
+	if t.columns == nil {
+		return rows
+	}
+	projected := make(TableRows, len(rows))
+	for i, row := range rows {
+		projected[i] = make([]string, len(t.columns))
+		for j, col := range t.columns {
+			if col < len(row) {
+				projected[i][j] = row[col]
+			}
+		}
+	}
+	return projected
+}
+
+// Position returns the offset from the first row of the table
+// and the position of the selected row relative to the offset.
+func (t *Table) Position() (offset, curr int) {
+	return t.offset, t.curr
+}
+
+// SetPosition sets the offset from the first row of the table and
+// the position of the selected row relative to the offset. Out of
+// bounds values are corrected on the next draw.
+func (t *Table) SetPosition(offset, curr int) {
+	if offset < 0 || curr < 0 {
+		return
+	}
+	t.offset = offset
+	t.prev = t.curr
+	t.curr = curr
+	t.keepPosition = true
+}
+
+// RowAt returns the index of the displayed row at the
+// y coordinate, or -1 if there is no row.
+func (t *Table) RowAt(y int) int {
+	row := y - t.Inner.Min.Y
+	if row < 0 || row >= len(t.Table.Rows) {
+		return -1
+	}
+	return row
+}
+
+// ColumnAt returns the index of the displayed column at the
+// x coordinate, or -1 if there is no column.
+func (t *Table) ColumnAt(x int) int {
+	widths, err := t.ColumnWidths()
+	if err != nil {
+		return -1
+	}
+	colX := t.Inner.Min.X
+	for i, width := range t.scrolledWidths(widths) {
+		// each column is followed by a separator.
+		if x >= colX && x <= colX+width {
+			if i == 0 {
+				return 0
+			}
+			return i + t.scroll
+		}
+		colX += width + 1
+	}
+	return -1
+}
+
+// SetScroll scrolls the displayed columns following the first one,
+// which stays in place, by the number of columns to the left.
+func (t *Table) SetScroll(columns int) {
+	if columns < 0 {
+		columns = 0
+	}
+	t.scroll = columns
+}
+
+// scrolled returns the rows without the columns scrolled out of the view.
+func (t *Table) scrolled(rows TableRows) TableRows {
+	if t.scroll == 0 {
+		return rows
+	}
+	scrolled := make(TableRows, len(rows))
+	for i, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		scrolled[i] = []string{row[0]}
+		if len(row) > 1+t.scroll {
+			scrolled[i] = append(scrolled[i], row[1+t.scroll:]...)
+		}
+	}
+	return scrolled
+}
+
+// scrolledWidths returns the widths without the
+// columns scrolled out of the view.
+func (t *Table) scrolledWidths(widths []int) []int {
+	if t.scroll == 0 || len(widths) <= 1+t.scroll {
+		return widths
+	}
+	return append([]int{widths[0]}, widths[1+t.scroll:]...)
+}
+
+// Select selects the displayed row at index.
+func (t *Table) Select(row int) {
+	if row < 0 || row >= t.visibleRows {
+		return
+	}
+	t.prev = t.curr
+	t.curr = row
+	t.paintActiveRow()
+}
+
+// SelectedEntry returns the displayed columns of all
+// rows of the entry which contains the selected row.
+func (t *Table) SelectedEntry() TableRows {
+	return t.project(t.SelectedRows())
+}
+
+// SelectedRows returns all columns of the rows
+// of the entry which contains the selected row.
+func (t *Table) SelectedRows() TableRows {
+	selected := t.offset + t.curr
+	if selected >= len(t.out) {
+		return nil
+	}
+	start := selected - selected%t.rowsPerEntry
+	end := start + t.rowsPerEntry
+	if end > len(t.out) {
+		end = len(t.out)
+	}
+	return t.out[start:end]
+}
+
+// SetSearch sets the text to search for. The search is case-insensitive
+// and matches the entries with the text in any of their rows.
+func (t *Table) SetSearch(text string) {
+	t.search = strings.ToLower(text)
+}
+
+// findMatches finds the entries of the displayed rows matching the search.
+func (t *Table) findMatches() {
+	t.matches = t.matches[:0]
+	if t.search == "" {
+		return
+	}
+	for start := 0; start < len(t.out); start += t.rowsPerEntry {
+		end := start + t.rowsPerEntry
+		if end > len(t.out) {
+			end = len(t.out)
+		}
+	entry:
+		for _, row := range t.out[start:end] {
+			for _, cell := range row {
+				if strings.Contains(strings.ToLower(cell), t.search) {
+					t.matches = append(t.matches, start)
+					break entry
+				}
+			}
+		}
+	}
+}
+
+// Matches returns the number of the matching entry with the selected
+// row, 0 if the selected entry doesn't match, and the number of matches.
+func (t *Table) Matches() (current, total int) {
+	selected := t.offset + t.curr
+	start := selected - selected%t.rowsPerEntry
+	i := sort.SearchInts(t.matches, start)
+	if i < len(t.matches) && t.matches[i] == start {
+		current = i + 1
+	}
+	return current, len(t.matches)
+}
+
+// NextMatch selects the first row of the next entry matching the
+// search, or of the previous one if forward is false. The search
+// wraps around at the end of the table.
+func (t *Table) NextMatch(forward bool) {
+	if len(t.matches) == 0 {
+		return
+	}
+	selected := t.offset + t.curr
+	start := selected - selected%t.rowsPerEntry
+
+	next := t.matches[0]
+	if forward {
+		if i := sort.SearchInts(t.matches, start+1); i < len(t.matches) {
+			next = t.matches[i]
+		}
+	} else {
+		next = t.matches[len(t.matches)-1]
+		if i := sort.SearchInts(t.matches, start); i > 0 {
+			next = t.matches[i-1]
+		}
+	}
+
+	t.prev = t.curr
+	if next >= t.offset && next < t.offset+t.visibleRows {
+		t.curr = next - t.offset
+	} else {
+		t.offset = next
+		t.curr = 0
+	}
+	t.keepPosition = true
+	t.paintActiveRow()
+}
+
 // resetPositions resets the positions into the table.
 func (t *Table) resetPositions() {
 	t.offset = 0
 	t.prev = t.curr
 	t.curr = 0
+	t.keepPosition = true
 }
 
 // SetRect resize the table, and correctly sets the height of the table.
@@ -227,6 +599,69 @@ func (t *Table) InitFilter(column, rowsPerEntry int) {
 	t.rowsPerEntry = rowsPerEntry
 }
 
+// SetKeyColumns sets the columns of the first row of an entry
+// which identify the entry.
+func (t *Table) SetKeyColumns(columns ...int) {
+	t.keyColumns = columns
+}
+
+// entryKey returns the key of the entry starting at the row,
+// or an empty string if the table has no key columns.
+func (t *Table) entryKey(row []string) string {
+	columns := t.keyColumns
+	if columns == nil && t.filterColumn >= 0 {
+		columns = []int{t.filterColumn}
+	}
+	var key []string
+	for _, col := range columns {
+		if col < len(row) {
+			key = append(key, row[col])
+		}
+	}
+	return strings.Join(key, "\x00")
+}
+
+// keyAt returns the key of the displayed entry starting at the row,
+// the headers of the groups are keyed by the group name.
+func (t *Table) keyAt(start int) string {
+	if t.isHeader[start] {
+		return "\x01" + t.groupOf[start]
+	}
+	return t.entryKey(t.out[start])
+}
+
+// selection returns the key of the entry with the selected row
+// and the position of the selected row within the entry.
+func (t *Table) selection() (key string, row int) {
+	selected := t.offset + t.curr
+	if selected >= len(t.out) {
+		return "", 0
+	}
+	start := selected - selected%t.rowsPerEntry
+	return t.keyAt(start), selected - start
+}
+
+// follow moves the selection to the entry with the key, keeping the
+// selected row at the same position on the screen if possible.
+func (t *Table) follow(key string, row int) {
+	for start := 0; start < len(t.out); start += t.rowsPerEntry {
+		if t.keyAt(start) != key {
+			continue
+		}
+		selected := start + row
+		if selected >= len(t.out) {
+			return
+		}
+		t.offset = selected - t.curr
+		if t.offset < 0 {
+			t.offset = 0
+		}
+		t.prev = t.curr
+		t.curr = selected - t.offset
+		return
+	}
+}
+
 // reCalcView recalculates the view into the table, handling any out of bounds errors.
 func (t *Table) reCalcView() {
 	if len(t.out) == 0 {
@@ -237,6 +672,14 @@ func (t *Table) reCalcView() {
 	if t.visibleRows < t.height-skipRows {
 		t.visibleRows = t.height - skipRows
 	}
+	// Avoid out of bounds offset if the number
+	// of rows decreased.
+	if t.offset >= len(t.out) {
+		t.offset = len(t.out) - t.visibleRows
+		if t.offset < 0 {
+			t.offset = 0
+		}
+	}
 	// Avoid overflow if the number of displayed rows
 	// is greater than the number of available rows.
 	if t.offset+t.visibleRows > len(t.out) {
@@ -247,20 +690,41 @@ func (t *Table) reCalcView() {
 	if t.visibleRows < 0 {
 		t.visibleRows = 0
 	}
-	t.Table.Rows = t.out[t.offset : t.offset+t.visibleRows]
+	// Keep the selected row within the displayed rows.
+	if t.curr >= t.visibleRows && t.visibleRows > 0 {
+		t.prev = t.curr
+		t.curr = t.visibleRows - 1
+	}
+	t.Table.Rows = t.scrolled(t.align(t.project(t.out[t.offset : t.offset+t.visibleRows])))
 }
 
 // Draw extends the method Draw from tui.Table to also include filtering.
 func (t *Table) Draw(buf *termui.Buffer) {
-	if t.filter.String() != "" && t.filterColumn >= 0 {
+	key, row := t.selection()
+	if t.keepPosition {
+		key, t.keepPosition = "", false
+	}
+
+	t.parseFilter()
+	outChanges := t.changes
+	if t.matcher != nil {
 		var filteredRows [][]string
-		for i := 0; i < len(t.Rows); i += t.rowsPerEntry {
-			if strings.Contains(t.Rows[i][t.filterColumn], t.filter.String()) {
+		var filteredEntries []int
+		outChanges = nil
+		for i := 0; i+t.rowsPerEntry <= len(t.Rows); i += t.rowsPerEntry {
+			if t.matcher(t.Rows[i : i+t.rowsPerEntry]) {
+				filteredEntries = append(filteredEntries, i/t.rowsPerEntry)
 				for r := 0; r < t.rowsPerEntry; r++ {
 					filteredRows = append(filteredRows, t.Rows[i+r])
+					var changes []Change
+					if i+r < len(t.changes) {
+						changes = t.changes[i+r]
+					}
+					outChanges = append(outChanges, changes)
 				}
 			}
 		}
+		filteredRows, outChanges = t.group(filteredRows, outChanges, filteredEntries)
 
 		// if no match against the filter
 		if len(filteredRows) == 0 {
@@ -277,15 +741,26 @@ func (t *Table) Draw(buf *termui.Buffer) {
 		}
 		t.out = filteredRows
 	} else {
-		t.out = t.Rows
+		t.out, outChanges = t.group(t.Rows, t.changes, nil)
 	}
 
+	if key != "" {
+		t.follow(key, row)
+	}
 	t.reCalcView()
+	t.findMatches()
 	// Avoid panic in the termui/table draw method, if no rows are supplied by the user.
 	if len(t.Table.Rows) == 0 {
 		return
 	}
 
+	t.paintMatches()
 	t.paintActiveRow()
+	// the widths of all displayed columns are kept
+	// for aligning the cells.
+	widths := t.Table.ColumnWidths
+	t.Table.ColumnWidths = t.scrolledWidths(widths)
 	t.Table.Draw(buf)
+	t.Table.ColumnWidths = widths
+	t.paintCells(buf, outChanges)
 }
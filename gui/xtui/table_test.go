@@ -18,6 +18,8 @@ package xtui
 
 import (
 	"testing"
+
+	"github.com/gizak/termui/v3"
 )
 
 func TestTable_AppendToFilter(t *testing.T) {
@@ -26,10 +28,10 @@ func TestTable_AppendToFilter(t *testing.T) {
 		input string
 		want  string
 	}{
-		{T: NewTable(false), input: "node", want: "node"},
-		{T: NewTable(false), input: "", want: ""},
-		{T: NewTable(false), input: "dpdk-65", want: "dpdk-65"},
-		{T: NewTable(false), input: "arm-pc", want: "arm-pc"},
+		{T: NewTable(), input: "node", want: "node"},
+		{T: NewTable(), input: "", want: ""},
+		{T: NewTable(), input: "dpdk-65", want: "dpdk-65"},
+		{T: NewTable(), input: "arm-pc", want: "arm-pc"},
 	}
 
 	for _, test := range tests {
@@ -50,14 +52,14 @@ func TestTable_ReduceFilter(t *testing.T) {
 		n     int
 		want  string
 	}{
-		{T: NewTable(false), input: "node", n: 1, want: "nod"},
-		{T: NewTable(false), input: "", n: 1, want: ""},
-		{T: NewTable(false), input: "", n: 2, want: ""},
-		{T: NewTable(false), input: "", n: -1, want: ""},
-		{T: NewTable(false), input: "arm-pc", n: 3, want: "arm"},
-		{T: NewTable(false), input: "arm-pc", n: 5, want: "a"},
-		{T: NewTable(false), input: "arm-pc", n: 6, want: ""},
-		{T: NewTable(false), input: "arm-pc", n: 7, want: "arm-pc"},
+		{T: NewTable(), input: "node", n: 1, want: "nod"},
+		{T: NewTable(), input: "", n: 1, want: ""},
+		{T: NewTable(), input: "", n: 2, want: ""},
+		{T: NewTable(), input: "", n: -1, want: ""},
+		{T: NewTable(), input: "arm-pc", n: 3, want: "arm"},
+		{T: NewTable(), input: "arm-pc", n: 5, want: "a"},
+		{T: NewTable(), input: "arm-pc", n: 6, want: ""},
+		{T: NewTable(), input: "arm-pc", n: 7, want: "arm-pc"},
 	}
 
 	for _, test := range tests {
@@ -85,12 +87,12 @@ func TestTable_ScrollUp(t *testing.T) {
 		wantPrev   int
 		wantOffset int
 	}{
-		{T: NewTable(false), visibleRows: 2, curr: 0, prev: 0, offset: 0, wantCurr: 0, wantPrev: 0, wantOffset: 0},
-		{T: NewTable(false), visibleRows: 2, curr: 1, prev: 0, offset: 0, wantCurr: 0, wantPrev: 1, wantOffset: 0},
-		{T: NewTable(false), visibleRows: 5, curr: 0, prev: 0, offset: 3, wantCurr: 0, wantPrev: 0, wantOffset: 2},
-		{T: NewTable(false), visibleRows: 10, curr: 2, prev: 1, offset: 5, wantCurr: 1, wantPrev: 2, wantOffset: 5},
-		{T: NewTable(false), visibleRows: 10, curr: 0, prev: 1, offset: 5, wantCurr: 0, wantPrev: 1, wantOffset: 4},
-		{T: NewTable(false), visibleRows: 10, curr: 0, prev: 1, offset: 0, wantCurr: 0, wantPrev: 1, wantOffset: 0},
+		{T: NewTable(), visibleRows: 2, curr: 0, prev: 0, offset: 0, wantCurr: 0, wantPrev: 0, wantOffset: 0},
+		{T: NewTable(), visibleRows: 2, curr: 1, prev: 0, offset: 0, wantCurr: 0, wantPrev: 1, wantOffset: 0},
+		{T: NewTable(), visibleRows: 5, curr: 0, prev: 0, offset: 3, wantCurr: 0, wantPrev: 0, wantOffset: 2},
+		{T: NewTable(), visibleRows: 10, curr: 2, prev: 1, offset: 5, wantCurr: 1, wantPrev: 2, wantOffset: 5},
+		{T: NewTable(), visibleRows: 10, curr: 0, prev: 1, offset: 5, wantCurr: 0, wantPrev: 1, wantOffset: 4},
+		{T: NewTable(), visibleRows: 10, curr: 0, prev: 1, offset: 0, wantCurr: 0, wantPrev: 1, wantOffset: 0},
 	}
 
 	for _, test := range tests {
@@ -129,11 +131,11 @@ func TestTable_ScrollDown(t *testing.T) {
 		wantPrev   int
 		wantOffset int
 	}{
-		{T: NewTable(false), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 0, prev: 0, offset: 0, wantCurr: 1, wantPrev: 0, wantOffset: 0},
-		{T: NewTable(false), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 1, prev: 0, offset: 0, wantCurr: 1, wantPrev: 0, wantOffset: 1},
-		{T: NewTable(false), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 0, prev: 0, offset: 3, wantCurr: 1, wantPrev: 0, wantOffset: 3},
-		{T: NewTable(false), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 1, prev: 0, offset: 3, wantCurr: 1, wantPrev: 0, wantOffset: 4},
-		{T: NewTable(false), visibleRows: 1, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 2, prev: 1, offset: 5, wantCurr: 2, wantPrev: 1, wantOffset: 5},
+		{T: NewTable(), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 0, prev: 0, offset: 0, wantCurr: 1, wantPrev: 0, wantOffset: 0},
+		{T: NewTable(), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 1, prev: 0, offset: 0, wantCurr: 1, wantPrev: 0, wantOffset: 1},
+		{T: NewTable(), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 0, prev: 0, offset: 3, wantCurr: 1, wantPrev: 0, wantOffset: 3},
+		{T: NewTable(), visibleRows: 2, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 1, prev: 0, offset: 3, wantCurr: 1, wantPrev: 0, wantOffset: 4},
+		{T: NewTable(), visibleRows: 1, out: TableRows{{""}, {""}, {""}, {""}, {""}, {""}}, curr: 2, prev: 1, offset: 5, wantCurr: 2, wantPrev: 1, wantOffset: 5},
 	}
 
 	for _, test := range tests {
@@ -158,3 +160,148 @@ func TestTable_ScrollDown(t *testing.T) {
 		}
 	}
 }
+
+func TestTable_ColumnAt(t *testing.T) {
+	table := NewTable()
+	// the inner area starts at x=1
+	table.SetRect(0, 0, 40, 10)
+	table.Table.ColumnWidths = []int{10, 5, 20}
+
+	tests := []struct {
+		x    int
+		want int
+	}{
+		{x: 0, want: -1},
+		{x: 1, want: 0},
+		{x: 11, want: 0},
+		{x: 12, want: 1},
+		{x: 17, want: 1},
+		{x: 18, want: 2},
+		{x: 38, want: 2},
+		{x: 39, want: -1},
+	}
+	for _, test := range tests {
+		if got := table.ColumnAt(test.x); got != test.want {
+			t.Errorf("Error occured got:%v; want:%v", got, test.want)
+		}
+	}
+}
+
+func TestTable_Scroll(t *testing.T) {
+	table := NewTable()
+	table.SetRect(0, 0, 40, 10)
+	table.Table.ColumnWidths = []int{10, 5, 20}
+	table.SetScroll(1)
+
+	got := table.scrolled(TableRows{{"a", "b", "c"}, {"d"}})
+	if len(got[0]) != 2 || got[0][0] != "a" || got[0][1] != "c" || len(got[1]) != 1 {
+		t.Errorf("Error occured got:%v; want:%v", got, TableRows{{"a", "c"}, {"d"}})
+	}
+	// the third column follows the first one.
+	if col := table.ColumnAt(12); col != 2 {
+		t.Errorf("Error occured got:%v; want:%v", col, 2)
+	}
+	if col := table.ColumnAt(5); col != 0 {
+		t.Errorf("Error occured got:%v; want:%v", col, 0)
+	}
+}
+
+func TestTable_SelectedEntry(t *testing.T) {
+	table := NewTable()
+	table.InitFilter(0, 2)
+	table.out = TableRows{{"a"}, {"a1"}, {"b"}, {"b1"}, {"c"}, {"c1"}}
+	table.visibleRows = 4
+	table.offset = 1
+	table.curr = 3
+
+	got := table.SelectedEntry()
+	if len(got) != 2 || got[0][0] != "c" || got[1][0] != "c1" {
+		t.Errorf("Error occured got:%v; want:%v", got, TableRows{{"c"}, {"c1"}})
+	}
+}
+
+func TestTable_Follow(t *testing.T) {
+	table := NewTable()
+	table.InitFilter(0, 2)
+	table.out = TableRows{{"a"}, {"a1"}, {"b"}, {"b1"}, {"c"}, {"c1"}}
+	table.offset = 0
+	table.curr = 3
+
+	key, row := table.selection()
+	table.out = TableRows{{"c"}, {"c1"}, {"b"}, {"b1"}, {"a"}, {"a1"}}
+	table.follow(key, row)
+	if table.offset+table.curr != 3 || table.curr != 3 {
+		t.Errorf("Error occured got:%v; want:%v", []int{table.offset, table.curr}, []int{0, 3})
+	}
+
+	table.out = TableRows{{"a"}, {"a1"}, {"c"}, {"c1"}, {"d"}, {"d1"}, {"b"}, {"b1"}}
+	table.follow(key, row)
+	if table.offset != 4 || table.curr != 3 {
+		t.Errorf("Error occured got:%v; want:%v", []int{table.offset, table.curr}, []int{4, 3})
+	}
+}
+
+func TestTable_NextMatch(t *testing.T) {
+	table := NewTable()
+	table.InitFilter(0, 2)
+	table.out = TableRows{{"a"}, {"x"}, {"b"}, {"b1"}, {"c"}, {"X2"}, {"d"}, {"d1"}}
+	table.visibleRows = 4
+	table.SetSearch("x")
+	table.findMatches()
+
+	if current, total := table.Matches(); current != 1 || total != 2 {
+		t.Errorf("Error occured got:%v; want:%v", []int{current, total}, []int{1, 2})
+	}
+	table.NextMatch(true)
+	if table.offset != 4 || table.curr != 0 {
+		t.Errorf("Error occured got:%v; want:%v", []int{table.offset, table.curr}, []int{4, 0})
+	}
+	if current, _ := table.Matches(); current != 2 {
+		t.Errorf("Error occured got:%v; want:%v", current, 2)
+	}
+	table.NextMatch(true)
+	if table.offset+table.curr != 0 {
+		t.Errorf("Error occured got:%v; want:%v", table.offset+table.curr, 0)
+	}
+	table.NextMatch(false)
+	if table.offset+table.curr != 4 {
+		t.Errorf("Error occured got:%v; want:%v", table.offset+table.curr, 4)
+	}
+}
+
+func TestTable_PaintActiveRow(t *testing.T) {
+	table := NewTable()
+	table.out = TableRows{{"x"}, {"b"}, {"c"}}
+	table.visibleRows = 3
+	table.SetSearch("x")
+	table.findMatches()
+	table.paintMatches()
+	table.paintActiveRow()
+
+	// move the selection off the matched row.
+	table.ScrollDown()
+	if got := table.RowStyles[0]; got != table.Styles.Match {
+		t.Errorf("Error occured got:%v; want:%v", got, table.Styles.Match)
+	}
+	if got := table.RowStyles[1]; got != table.Styles.SelectedRow {
+		t.Errorf("Error occured got:%v; want:%v", got, table.Styles.SelectedRow)
+	}
+}
+
+func TestTable_PaintActiveRowHighlighted(t *testing.T) {
+	alert := termui.NewStyle(termui.ColorWhite, termui.ColorRed)
+	table := NewTable()
+	table.out = TableRows{{"alert"}, {"b"}}
+	table.visibleRows = 2
+	table.SetEntryHighlighter(func(entry TableRows) (termui.Style, bool) {
+		return alert, entry[0][0] == "alert"
+	})
+	table.paintMatches()
+	table.paintActiveRow()
+
+	// move the selection off the highlighted row.
+	table.ScrollDown()
+	if got := table.RowStyles[0]; got != alert {
+		t.Errorf("Error occured got:%v; want:%v", got, alert)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2019 PANTHEON.tech.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xtui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// siPrefixes are the prefixes of the formatted numbers,
+// each one is a thousand times the previous one.
+var siPrefixes = []string{"", "k", "M", "G", "T"}
+
+// binaryPrefixes are the prefixes of the formatted numbers,
+// each one is 1024 times the previous one.
+var binaryPrefixes = []string{"", "Ki", "Mi", "Gi", "Ti"}
+
+// FormatNumber formats the number with a SI prefix and the unit,
+// e.g. 1.5 Mbps. The number is formatted with at most one decimal.
+// The result can be parsed by ParseNumber.
+func FormatNumber(v float64, unit string) string {
+	return formatPrefixed(v, unit, 1000, siPrefixes)
+}
+
+// FormatBinary formats the number with a binary prefix
+// and the unit, e.g. 1.5 MiB. The result can be parsed
+// by ParseNumber.
+func FormatBinary(v float64, unit string) string {
+	return formatPrefixed(v, unit, 1024, binaryPrefixes)
+}
+
+// formatPrefixed formats the number with the prefix of its magnitude,
+// the number without unit is followed by the prefix without a space.
+func formatPrefixed(v float64, unit string, base float64, prefixes []string) string {
+	prefix := 0
+	for math.Abs(v) >= base && prefix < len(prefixes)-1 {
+		v /= base
+		prefix++
+	}
+	s := strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0")
+	if unit == "" {
+		return s + prefixes[prefix]
+	}
+	return s + " " + prefixes[prefix] + unit
+}
@@ -21,12 +21,14 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"git.fd.io/govpp.git"
 	"git.fd.io/govpp.git/adapter"
+	"git.fd.io/govpp.git/adapter/socketclient"
 	"git.fd.io/govpp.git/adapter/statsclient"
 	"git.fd.io/govpp.git/api"
 	"git.fd.io/govpp.git/core"
@@ -72,8 +74,18 @@ const (
 	stateDown = "down"
 )
 
+// clockNow matches the seconds since VPP started in the output of show clock.
+var clockNow = regexp.MustCompile(`Time now ([0-9.]+)`)
+
+// link duplex of the interfaces.
+const (
+	DuplexFull = "full"
+	DuplexHalf = "half"
+)
+
 var (
-	DefaultSocket = adapter.DefaultStatsSocket
+	DefaultSocket       = adapter.DefaultStatsSocket
+	DefaultBinapiSocket = socketclient.DefaultSocketName
 )
 
 type (
@@ -98,6 +110,11 @@ type (
 		IPAddrs []string
 		State   string
 		MTU     []uint32
+		// LinkSpeed is the speed of the link in bits
+		// per second, 0 if the interface doesn't report it.
+		LinkSpeed uint64
+		// Duplex is DuplexFull, DuplexHalf or empty if unknown.
+		Duplex string
 	}
 
 	ThreadData struct {
@@ -109,6 +126,14 @@ type (
 		Core      uint32
 		CPUSocket uint32
 	}
+	// ThreadRuntime is the runtime summary of a thread.
+	ThreadRuntime struct {
+		ID   uint
+		Name string
+		// AvgVectorsPerNode is the average number of
+		// vectors processed by a node of the thread.
+		AvgVectorsPerNode float64
+	}
 	Node   telemetry.RuntimeItem
 	Error  telemetry.NodeCounter
 	Memory telemetry.MemoryThread
@@ -191,8 +216,9 @@ func (s *VPP) ConnectRemote(raddr string) error {
 	return nil
 }
 
-// Connect establishes a connection to govpp API.
-func (s *VPP) Connect(soc string) error {
+// Connect establishes a connection to govpp API, using the stats
+// socket at soc and the binary API socket at binapiSoc.
+func (s *VPP) Connect(soc, binapiSoc string) error {
 	s.lastErrorCounters = make(map[string]uint64)
 
 	s.client = statsclient.NewStatsClient(soc)
@@ -203,7 +229,7 @@ func (s *VPP) Connect(soc string) error {
 		return fmt.Errorf("connection to stats api failed: %v", err)
 	}
 
-	s.vppConn, err = govpp.Connect("")
+	s.vppConn, err = core.Connect(socketclient.NewVppClient(binapiSoc))
 	if err != nil {
 		return fmt.Errorf("connection to govpp failed: %v", err)
 	}
@@ -253,21 +279,52 @@ func (s *VPP) Disconnect() {
 
 // GetNodes returns per node statistics.
 func (s *VPP) GetNodes() ([]Node, error) {
+	nodes, _, err := s.GetRuntime()
+	return nodes, err
+}
+
+// GetRuntime returns per node statistics and the runtime
+// summary of each thread from a single runtime info request.
+func (s *VPP) GetRuntime() ([]Node, []ThreadRuntime, error) {
 	runtimeCounters, err := s.telemetryHandler.GetRuntimeInfo(context.TODO())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	threads := runtimeCounters.GetThreads()
 	if len(threads) == 0 {
-		return nil, errors.New("No runtime counters")
+		return nil, nil, errors.New("No runtime counters")
 	}
-	result := make([]Node, 0, len(threads[0].Items))
-	for _, thread := range threads {
+	nodes := make([]Node, 0, len(threads[0].Items))
+	summary := make([]ThreadRuntime, len(threads))
+	for i, thread := range threads {
 		for _, item := range thread.Items {
-			result = append(result, Node(item))
+			nodes = append(nodes, Node(item))
+		}
+		summary[i] = ThreadRuntime{
+			ID:                thread.ID,
+			Name:              thread.Name,
+			AvgVectorsPerNode: thread.AvgVectorsPerNode,
 		}
 	}
-	return result, nil
+	return nodes, summary, nil
+}
+
+// Uptime returns the time since VPP started. Unlike the time
+// in show runtime, it is not reset by clear runtime.
+func (s *VPP) Uptime() (time.Duration, error) {
+	clock, err := s.govppHandler.RunCli("show clock")
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %v", err)
+	}
+	match := clockNow.FindStringSubmatch(clock)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected show clock output: %q", clock)
+	}
+	secs, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(secs * float64(time.Second)), nil
 }
 
 // GetInterfaces returns per interface statistics.
@@ -315,11 +372,40 @@ func (s *VPP) GetInterfaces() ([]Interface, error) {
 			IPAddrs:           details.Interface.GetIpAddresses(),
 			State:             state,
 			MTU:               details.Meta.MTU,
+			// the link speed is reported in kbps.
+			LinkSpeed: uint64(details.Meta.LinkSpeed) * 1000,
+			Duplex:    duplex(details.Meta.LinkDuplex),
 		})
 	}
 	return result, nil
 }
 
+// GetInterfaceCounters returns the counters of the interfaces read
+// from the stats segment, without the details requested by the binary
+// API. It is cheap enough to be called many times per second.
+func (s *VPP) GetInterfaceCounters() ([]Interface, error) {
+	ifaceStats, err := s.telemetryHandler.GetInterfaceStats(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	result := make([]Interface, len(ifaceStats.Interfaces))
+	for i, iface := range ifaceStats.Interfaces {
+		result[i].InterfaceCounters = iface
+	}
+	return result, nil
+}
+
+// duplex returns the name of the link duplex reported by VPP.
+func duplex(d uint32) string {
+	switch d {
+	case 1:
+		return DuplexHalf
+	case 2:
+		return DuplexFull
+	}
+	return ""
+}
+
 // GetErrors returns per error statistics.
 func (s *VPP) GetErrors() ([]Error, error) {
 	counters, err := s.telemetryHandler.GetNodeCounters(context.TODO())
@@ -329,9 +415,6 @@ func (s *VPP) GetErrors() ([]Error, error) {
 	result := make([]Error, 0)
 	for _, counter := range counters.GetCounters() {
 		counter.Value -= s.lastErrorCounters[counter.Node+counter.Name]
-		if counter.Value == 0 {
-			continue
-		}
 		result = append(result, Error(counter))
 	}
 	return result, nil